@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"telegram-chat-analyzer/internal/delivery"
+	"telegram-chat-analyzer/internal/infrastructure"
 	"telegram-chat-analyzer/internal/repository"
 	"telegram-chat-analyzer/internal/usecase"
 
@@ -42,8 +43,39 @@ func main() {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 
+	// Load optional analysis configuration
+	scoreProfiles, err := infrastructure.LoadScoreProfiles(os.Getenv("SCORE_PROFILES_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	lexiconData, err := infrastructure.LoadLexicons(os.Getenv("SENTIMENT_LEXICONS_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	questionRules, err := infrastructure.LoadQuestionRules(os.Getenv("QUESTION_RULES_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	ritualPatterns, err := infrastructure.LoadRitualPatterns(os.Getenv("RITUAL_PATTERNS_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	milestonePhrases, err := infrastructure.LoadMilestonePhrases(os.Getenv("MILESTONE_PHRASES_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := usecase.Config{
+		ScoreProfiles:    scoreProfiles,
+		QuestionRules:    questionRules,
+		RitualPatterns:   ritualPatterns,
+		MilestonePhrases: milestonePhrases,
+	}
+	for language, data := range lexiconData {
+		config.Lexicons = append(config.Lexicons, usecase.NewLexicon(language, data))
+	}
+
 	// Initialize use case
-	uc := usecase.NewMessageUsecase()
+	uc := usecase.NewMessageUsecase(config)
 
 	// Set up Gin
 	r := gin.Default()
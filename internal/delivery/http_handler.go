@@ -3,6 +3,8 @@ package delivery
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 
 	"telegram-chat-analyzer/internal/domain"
@@ -28,18 +30,42 @@ func NewMessageHandler(router *gin.Engine, uc usecase.MessageUsecase, repo repos
 	router.POST("/topSixWords", handler.ProcessMessages)                                     // return top 6 frequent words
 	router.POST("/countMessages", handler.CountMessages)                                     // count total messages sent by each person
 	router.POST("/countWords", handler.countWords)                                           // return shared interests
+	router.POST("/termTimeline", handler.TermTimeline)                                       // return first use, last use and monthly usage of words or phrases
+	router.GET("/chats/:id/search", handler.Search)                                          // search a stored chat for words and "quoted phrases"
 	router.POST("/totalDaysTalked", handler.totalDaysTalked)                                 // return total active days
 	router.POST("/messagesPerDay", handler.MessagesPerDay)                                   // return each day messages
+	router.POST("/timeSeries", handler.TimeSeries)                                           // return gap-filled message, word, character and media series
+	router.POST("/anomalies", handler.Anomalies)                                             // return unusual days or weeks in volume, reply time and sentiment
+	router.POST("/forecast", handler.Forecast)                                               // return projected daily and weekly volume and the next message milestone
+	router.POST("/milestones", handler.Milestones)                                           // return firsts, message counts, anniversaries and records in date order
+	router.POST("/wrapped", handler.Wrapped)                                                 // return the year in review: top words and emoji, busiest periods, streaks, replies and calls
 	router.POST("/averageMessagesPerDay", handler.AverageMessagesPerDay)                     // return average messages per day for each person
 	router.POST("/weeklyStats", handler.WeeklyStats)                                         // return number of messages on each day of the week
 	router.POST("/hourlyStats", handler.hourlyStats)                                         // return number of messages on each hour of the day
+	router.POST("/activityHeatmap", handler.ActivityHeatmap)                                 // return messages per weekday and hour
+	router.POST("/chronotypes", handler.Chronotypes)                                         // return each person's daily rhythm, sleep window and overlap
 	router.POST("/mostActiveDayOfWeek", handler.MostActiveDayOfWeek)                         // return most active day of the week
 	router.POST("/messageLengthStatistics", handler.MessageLengthStatistics)                 // return average char per text total char max and min
 	router.POST("/replyTimeAnalysis", handler.ReplyTimeAnalysis)                             // return the average time taken to reply
+	router.POST("/bursts", handler.Bursts)                                                   // return double-texting, burst lengths and longest monologues
+	router.POST("/turnTaking", handler.TurnTaking)                                           // return turns per session, turn lengths, interruptions and monthly balance
+	router.POST("/silences", handler.Silences)                                               // return longest silences, who broke them and who left whom on read
 	router.POST("/countConversationStartersPerDay", handler.CountConversationStartersPerDay) // return the number of conversation starters per day
 	router.POST("/countConsecutiveDays", handler.CountConsecutiveDays)                       // return the number of consecutive days talked
+	router.POST("/distinctiveWords", handler.DistinctiveWords)                               // return each person's most distinctive words and the truly shared ones
+	router.POST("/phrases", handler.Phrases)                                                 // return frequent bigrams, trigrams and catchphrases
+	router.POST("/lexicalDiversity", handler.LexicalDiversity)                               // return vocabulary richness and monthly new words per person
+	router.POST("/topics", handler.Topics)                                                   // return topics discussed across sessions or days
+	router.POST("/sentiment", handler.Sentiment)                                             // return lexicon-based mood per person and per day
+	router.POST("/questions", handler.Questions)                                             // return questions asked, answer rate and unanswered questions
+	router.POST("/rituals", handler.Rituals)                                                 // return good morning / good night habits and streaks
+	router.POST("/laughter", handler.Laughter)                                               // return who makes whom laugh and the funniest messages
+	router.POST("/links", handler.Links)                                                     // return shared links, top domains and repeated links
+	router.POST("/entities", handler.Entities)                                               // return formatting, mention and hashtag usage per person
 	router.POST("/relationshipScore", handler.RelationshipScore)
+	router.POST("/relationshipScoreTrend", handler.RelationshipScoreTrend) // return the relationship score per week, month or quarter
 	router.POST("/currentStreak", handler.CurrentStreak)
+	router.POST("/streaks", handler.Streaks) // return longest, current and all streaks per person
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "Welcome to Telegram Chat Analyzer!"})
 	})
@@ -64,8 +90,19 @@ func (h *MessageHandler) ProcessMessages(c *gin.Context) {
 		"topSixWords": topSixWords,
 	}
 
-	// Save to MongoDB
-	if err := h.repo.SaveProcessedData(context.Background(), h.collection, chat); err != nil {
+	// Save to MongoDB together with the search index of the chat. The index is
+	// built first, and the chat is removed again if its index cannot be saved
+	// so that every stored chat stays searchable.
+	index := h.usecase.BuildSearchIndex(chat)
+	chatID, err := h.repo.SaveProcessedData(context.Background(), h.collection, chat)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save data to database: " + err.Error()})
+		return
+	}
+	if err := h.repo.SaveSearchIndex(context.Background(), h.collection, chatID, index); err != nil {
+		if deleteErr := h.repo.DeleteChat(context.Background(), h.collection, chatID); deleteErr != nil {
+			log.Printf("Failed to roll back chat %s: %v", chatID, deleteErr)
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save data to database: " + err.Error()})
 		return
 	}
@@ -73,6 +110,7 @@ func (h *MessageHandler) ProcessMessages(c *gin.Context) {
 	// Respond with success and data
 	c.JSON(http.StatusOK, gin.H{
 		"message":       "Successfully processed messages",
+		"chatId":        chatID,
 		"processedData": result,
 	})
 }
@@ -139,6 +177,35 @@ func (h *MessageHandler) MessagesPerDay(c *gin.Context) {
 	})
 }
 
+func (h *MessageHandler) TimeSeries(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseSeriesOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	timeSeries, err := h.usecase.TimeSeries(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Successfully calculated time series",
+		"timeSeries": timeSeries,
+	})
+}
+
 func (h *MessageHandler) WeeklyStats(c *gin.Context) {
 	var chat domain.Chat
 	if err := c.ShouldBindJSON(&chat); err != nil {
@@ -177,6 +244,35 @@ func (h *MessageHandler) hourlyStats(c *gin.Context) {
 	})
 }
 
+func (h *MessageHandler) ActivityHeatmap(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseHeatmapOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	heatmap, err := h.usecase.ActivityHeatmap(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully calculated activity heatmap",
+		"heatmap": heatmap,
+	})
+}
+
 func (h *MessageHandler) MostActiveDayOfWeek(c *gin.Context) {
 	var chat domain.Chat
 	if err := c.ShouldBindJSON(&chat); err != nil {
@@ -268,7 +364,13 @@ func (h *MessageHandler) CountConsecutiveDays(c *gin.Context) {
 		return
 	}
 
-	consecutiveDays, err := h.usecase.CountConsecutiveDays(chat)
+	opts, err := parseStreakOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	consecutiveDays, err := h.usecase.CountConsecutiveDays(chat, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
 		return
@@ -298,6 +400,153 @@ func (h *MessageHandler) GetSharedInterests(c *gin.Context) {
 	})
 }
 
+func (h *MessageHandler) DistinctiveWords(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseVocabularyOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	vocabulary, err := h.usecase.DistinctiveVocabulary(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Successfully ranked distinctive words",
+		"distinctive": vocabulary.Distinctive,
+		"shared":      vocabulary.Shared,
+	})
+}
+
+func (h *MessageHandler) Phrases(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parsePhraseOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	phrases, err := h.usecase.Phrases(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully detected phrases",
+		"phrases": phrases,
+	})
+}
+
+func (h *MessageHandler) LexicalDiversity(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	loc, err := parseLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	diversity, err := h.usecase.LexicalDiversity(chat, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Successfully measured lexical diversity",
+		"diversity": diversity,
+	})
+}
+
+func (h *MessageHandler) Topics(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseTopicOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	topics, err := h.usecase.Topics(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully modeled topics",
+		"topics":  topics,
+	})
+}
+
+func (h *MessageHandler) Sentiment(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseSentimentOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	// The only failure is an unknown language, which is the caller's mistake.
+	sentiment, err := h.usecase.Sentiment(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Successfully analyzed sentiment",
+		"sentiment": sentiment,
+	})
+}
+
 func (h *MessageHandler) AverageMessagesPerDay(c *gin.Context) {
 	var chat domain.Chat
 
@@ -342,7 +591,60 @@ func (h *MessageHandler) countWords(c *gin.Context) {
 	})
 }
 
-func (h *MessageHandler) RelationshipScore(c *gin.Context) {
+func (h *MessageHandler) Search(c *gin.Context) {
+	opts, err := parseSearchOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	// Only the messages a query needs are read from the stored chat, unless
+	// it was saved before indexing existed: then it is loaded and indexed on
+	// first search. Chats without words simply have nothing to find.
+	chatID := c.Param("id")
+	load := func(positions []int) (map[int]domain.Message, error) {
+		return h.repo.FindMessages(context.Background(), h.collection, chatID, positions)
+	}
+	postings, err := h.repo.FindPostings(context.Background(), h.collection, chatID, usecase.SearchTerms(opts.Phrases))
+	if errors.Is(err, repository.ErrNotIndexed) {
+		chat, err := h.repo.FindChat(context.Background(), h.collection, chatID)
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Chat not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load data from database: " + err.Error()})
+			return
+		}
+		postings = h.usecase.BuildSearchIndex(chat)
+		if len(postings) > 0 {
+			if err := h.repo.SaveSearchIndex(context.Background(), h.collection, chatID, postings); err != nil {
+				log.Printf("Failed to index chat %s: %v", chatID, err)
+			}
+		}
+		load = usecase.ChatMessages(chat)
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load data from database: " + err.Error()})
+		return
+	}
+
+	result, err := h.usecase.Search(postings, load, opts)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chat not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully searched messages",
+		"results": result,
+	})
+}
+
+func (h *MessageHandler) TermTimeline(c *gin.Context) {
 	var chat domain.Chat
 	if err := c.ShouldBindJSON(&chat); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
@@ -353,19 +655,90 @@ func (h *MessageHandler) RelationshipScore(c *gin.Context) {
 		return
 	}
 
-	score, err := h.usecase.RelationshipScore(chat)
+	queries, err := parseTermQueries(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	loc, err := parseLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	timeline, err := h.usecase.TermTimeline(chat, queries, loc)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Successfully calculated relationship score",
-		"score":   score,
+		"message":  "Successfully traced term usage",
+		"timeline": timeline,
 	})
 }
 
-func (h *MessageHandler) CurrentStreak(c *gin.Context) {
+func (h *MessageHandler) Questions(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseQuestionOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	// The only failure is an unknown language, which is a client error.
+	questions, err := h.usecase.Questions(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Successfully detected questions",
+		"questions": questions,
+	})
+}
+
+func (h *MessageHandler) Rituals(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseRitualOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	// Unknown languages are the only way this fails.
+	rituals, err := h.usecase.Rituals(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully detected rituals",
+		"rituals": rituals,
+	})
+}
+
+func (h *MessageHandler) Laughter(c *gin.Context) {
 	var chat domain.Chat
 	if err := c.ShouldBindJSON(&chat); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
@@ -376,14 +749,444 @@ func (h *MessageHandler) CurrentStreak(c *gin.Context) {
 		return
 	}
 
-	streak, err := h.usecase.CurrentStreak(chat)
+	opts, err := parseLaughterOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	laughter, err := h.usecase.Laughter(chat, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":       "Successfully calculated current streak",
-		"currentStreak": streak,
+		"message":  "Successfully measured laughter",
+		"laughter": laughter,
+	})
+}
+
+func (h *MessageHandler) Links(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseLinkOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	links, err := h.usecase.Links(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully analyzed links",
+		"links":   links,
+	})
+}
+
+func (h *MessageHandler) Entities(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	top, err := parseIntQuery(c, "top", 10)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	entities, err := h.usecase.Entities(chat, top)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Successfully analyzed text entities",
+		"entities": entities,
+	})
+}
+
+func (h *MessageHandler) Bursts(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseBurstOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	bursts, err := h.usecase.Bursts(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully analyzed message bursts",
+		"bursts":  bursts,
+	})
+}
+
+func (h *MessageHandler) TurnTaking(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseTurnOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	turnTaking, err := h.usecase.TurnTaking(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Successfully analyzed turn-taking",
+		"turnTaking": turnTaking,
+	})
+}
+
+func (h *MessageHandler) Silences(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseSilenceOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	silences, err := h.usecase.Silences(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Successfully detected silences",
+		"silences": silences,
+	})
+}
+
+func (h *MessageHandler) Chronotypes(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseChronotypeOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	chronotypes, err := h.usecase.Chronotypes(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Successfully profiled daily rhythms",
+		"chronotypes": chronotypes,
+	})
+}
+
+func (h *MessageHandler) Anomalies(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseAnomalyOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	anomalies, err := h.usecase.Anomalies(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Successfully detected anomalies",
+		"anomalies": anomalies,
+	})
+}
+
+func (h *MessageHandler) Forecast(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseForecastOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	forecast, err := h.usecase.Forecast(chat, opts)
+	if errors.Is(err, usecase.ErrNotEnoughHistory) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Successfully forecast message volume",
+		"forecast": forecast,
+	})
+}
+
+func (h *MessageHandler) Milestones(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	var opts usecase.MilestoneOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err == nil {
+		opts.SessionGap, err = parseSessionGap(c)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	milestones, err := h.usecase.Milestones(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Successfully found milestones",
+		"milestones": milestones,
+	})
+}
+
+func (h *MessageHandler) Wrapped(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseWrappedOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	wrapped, err := h.usecase.Wrapped(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully built year in review",
+		"wrapped": wrapped,
+	})
+}
+
+func (h *MessageHandler) RelationshipScore(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	weights, err := parseScoreWeights(c, h.usecase)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	score, err := h.usecase.RelationshipScore(chat, weights)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Successfully calculated relationship score",
+		"score":      score.Score,
+		"components": score.Components,
+		"weights":    weights,
+	})
+}
+
+func (h *MessageHandler) RelationshipScoreTrend(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	weights, err := parseScoreWeights(c, h.usecase)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	opts, err := parseTrendOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	trend, err := h.usecase.RelationshipScoreTrend(chat, weights, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully calculated relationship score trend",
+		"period":  opts.Period,
+		"window":  opts.Window,
+		"weights": weights,
+		"trend":   trend,
+	})
+}
+
+func (h *MessageHandler) CurrentStreak(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseStreakOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	streak, err := h.usecase.CurrentStreak(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Successfully calculated current streak",
+		"currentStreak": streak,
+	})
+}
+
+func (h *MessageHandler) Streaks(c *gin.Context) {
+	var chat domain.Chat
+	if err := c.ShouldBindJSON(&chat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data: " + err.Error()})
+		return
+	}
+	if len(chat.Messages) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No messages provided in the input data"})
+		return
+	}
+
+	opts, err := parseStreakOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	streaks, err := h.usecase.Streaks(chat, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process messages: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully calculated streaks",
+		"streaks": streaks,
 	})
 }
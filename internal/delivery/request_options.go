@@ -0,0 +1,624 @@
+// internal/delivery/request_options.go
+package delivery
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+	"telegram-chat-analyzer/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseLocation reads the optional "timezone" query parameter (an IANA name
+// such as Africa/Addis_Ababa). A missing value returns nil, which keeps the
+// timestamps exactly as they appear in the export.
+func parseLocation(c *gin.Context) (*time.Location, error) {
+	name := c.Query("timezone")
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", name)
+	}
+	return loc, nil
+}
+
+// parseAsOf reads the optional "asOf" query parameter, formatted as
+// 2006-01-02T15:04:05 or 2006-01-02, in loc. A nil loc reads it on the
+// export's wall clock, like the message dates. A missing value returns the
+// zero time.
+func parseAsOf(c *gin.Context, loc *time.Location) (time.Time, error) {
+	raw := c.Query("asOf")
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+		if asOf, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return asOf, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("asOf must be formatted as YYYY-MM-DD or YYYY-MM-DDTHH:MM:SS")
+}
+
+// parseIntQuery reads a non-negative integer query parameter, falling back
+// to def when it is absent.
+func parseIntQuery(c *gin.Context, key string, def int) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return def, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", key)
+	}
+	return value, nil
+}
+
+// parseStreakOptions reads timezone, asOf (see parseAsOf), grace and minLength.
+func parseStreakOptions(c *gin.Context) (usecase.StreakOptions, error) {
+	var opts usecase.StreakOptions
+	loc, err := parseLocation(c)
+	if err != nil {
+		return opts, err
+	}
+	opts.Location = loc
+
+	if opts.AsOf, err = parseAsOf(c, loc); err != nil {
+		return opts, err
+	}
+	if opts.GraceDays, err = parseIntQuery(c, "grace", 0); err != nil {
+		return opts, err
+	}
+	if opts.MinLength, err = parseIntQuery(c, "minLength", 2); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseScoreWeights resolves relationship score weights from the optional
+// "profile" query parameter and per-component overrides passed as
+// weights[replyTime]=0.5.
+func parseScoreWeights(c *gin.Context, uc usecase.MessageUsecase) (domain.ScoreWeights, error) {
+	overrides := make(map[string]float64)
+	for name, raw := range c.QueryMap("weights") {
+		weight, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("weight for %q must be a number", name)
+		}
+		overrides[name] = weight
+	}
+	return uc.ScoreWeights(c.Query("profile"), overrides)
+}
+
+// parseTrendOptions reads timezone, period (week, month or quarter; default
+// month) and window (number of periods per score; default 1).
+func parseTrendOptions(c *gin.Context) (usecase.TrendOptions, error) {
+	var opts usecase.TrendOptions
+	loc, err := parseLocation(c)
+	if err != nil {
+		return opts, err
+	}
+	opts.Location = loc
+	opts.Period = c.DefaultQuery("period", usecase.GranularityMonth)
+	switch opts.Period {
+	case usecase.GranularityWeek, usecase.GranularityMonth, usecase.GranularityQuarter:
+	default:
+		return opts, fmt.Errorf("period must be week, month or quarter")
+	}
+	if opts.Window, err = parseIntQuery(c, "window", 1); err != nil {
+		return opts, err
+	}
+	if opts.Window == 0 {
+		return opts, fmt.Errorf("window must be at least 1")
+	}
+	return opts, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// parseWeekStart reads the optional "weekStart" query parameter, defaulting to monday.
+func parseWeekStart(c *gin.Context) (time.Weekday, error) {
+	name := strings.ToLower(c.DefaultQuery("weekStart", "monday"))
+	weekday, ok := weekdays[name]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekStart %q", name)
+	}
+	return weekday, nil
+}
+
+// parseSeriesOptions reads timezone, granularity (default day), weekStart,
+// rolling (window size for the rolling average) and cumulative.
+func parseSeriesOptions(c *gin.Context) (usecase.SeriesOptions, error) {
+	var opts usecase.SeriesOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.WeekStart, err = parseWeekStart(c); err != nil {
+		return opts, err
+	}
+	opts.Granularity = c.DefaultQuery("granularity", usecase.GranularityDay)
+	switch opts.Granularity {
+	case usecase.GranularityDay, usecase.GranularityWeek, usecase.GranularityMonth, usecase.GranularityYear:
+	default:
+		return opts, fmt.Errorf("granularity must be day, week, month or year")
+	}
+	if opts.RollingWindow, err = parseIntQuery(c, "rolling", 0); err != nil {
+		return opts, err
+	}
+	if raw := c.Query("cumulative"); raw != "" {
+		if opts.Cumulative, err = strconv.ParseBool(raw); err != nil {
+			return opts, fmt.Errorf("cumulative must be true or false")
+		}
+	}
+	return opts, nil
+}
+
+// parseHeatmapOptions reads timezone, weekStart and normalize (none, total or max).
+func parseHeatmapOptions(c *gin.Context) (usecase.HeatmapOptions, error) {
+	var opts usecase.HeatmapOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.WeekStart, err = parseWeekStart(c); err != nil {
+		return opts, err
+	}
+	opts.Normalize = c.DefaultQuery("normalize", usecase.NormalizeNone)
+	switch opts.Normalize {
+	case usecase.NormalizeNone, usecase.NormalizeTotal, usecase.NormalizeMax:
+	default:
+		return opts, fmt.Errorf("normalize must be none, total or max")
+	}
+	return opts, nil
+}
+
+// parseSentimentOptions reads timezone, languages (comma separated),
+// messages (include per-message polarity), minDayMessages and topDays.
+func parseSentimentOptions(c *gin.Context) (usecase.SentimentOptions, error) {
+	var opts usecase.SentimentOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	opts.Languages = parseLanguages(c)
+	if raw := c.Query("messages"); raw != "" {
+		if opts.IncludeMessages, err = strconv.ParseBool(raw); err != nil {
+			return opts, fmt.Errorf("messages must be true or false")
+		}
+	}
+	if opts.MinDayMessages, err = parseIntQuery(c, "minDayMessages", 3); err != nil {
+		return opts, err
+	}
+	if opts.TopDays, err = parseIntQuery(c, "topDays", 5); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseVocabularyOptions reads timezone, maxNgram (1-3, default 2),
+// minCount (default 3) and top (default 20).
+func parseVocabularyOptions(c *gin.Context) (usecase.VocabularyOptions, error) {
+	var opts usecase.VocabularyOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.MaxNgram, err = parseIntQuery(c, "maxNgram", 2); err != nil {
+		return opts, err
+	}
+	if opts.MaxNgram < 1 || opts.MaxNgram > 3 {
+		return opts, fmt.Errorf("maxNgram must be between 1 and 3")
+	}
+	if opts.MinCount, err = parseIntQuery(c, "minCount", 3); err != nil {
+		return opts, err
+	}
+	if opts.Top, err = parseIntQuery(c, "top", 20); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parsePhraseOptions reads timezone, minCount (default 3), minDays (default 3)
+// and top (default 20).
+func parsePhraseOptions(c *gin.Context) (usecase.PhraseOptions, error) {
+	var opts usecase.PhraseOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.MinCount, err = parseIntQuery(c, "minCount", 3); err != nil {
+		return opts, err
+	}
+	if opts.MinDays, err = parseIntQuery(c, "minDays", 3); err != nil {
+		return opts, err
+	}
+	if opts.Top, err = parseIntQuery(c, "top", 20); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseSessionGap reads the optional "sessionGap" query parameter in minutes.
+func parseSessionGap(c *gin.Context) (time.Duration, error) {
+	minutes, err := parseIntQuery(c, "sessionGap", int(usecase.DefaultSessionGap/time.Minute))
+	if err != nil {
+		return 0, err
+	}
+	if minutes == 0 {
+		return 0, fmt.Errorf("sessionGap must be at least 1 minute")
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// parseTopicOptions reads timezone, unit (session or day), sessionGap,
+// topics (1-20, default 6), iterations (1-300, default 150) and terms (default 10).
+func parseTopicOptions(c *gin.Context) (usecase.TopicOptions, error) {
+	var opts usecase.TopicOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	opts.Unit = c.DefaultQuery("unit", usecase.TopicUnitSession)
+	if opts.Unit != usecase.TopicUnitSession && opts.Unit != usecase.TopicUnitDay {
+		return opts, fmt.Errorf("unit must be session or day")
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	if opts.Topics, err = parseIntQuery(c, "topics", 6); err != nil {
+		return opts, err
+	}
+	if opts.Topics < 1 || opts.Topics > 20 {
+		return opts, fmt.Errorf("topics must be between 1 and 20")
+	}
+	if opts.Iterations, err = parseIntQuery(c, "iterations", 150); err != nil {
+		return opts, err
+	}
+	if opts.Iterations < 1 || opts.Iterations > 300 {
+		return opts, fmt.Errorf("iterations must be between 1 and 300")
+	}
+	if opts.TermsPerTopic, err = parseIntQuery(c, "terms", 10); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// maxTermQueries caps how many terms one timeline request may trace.
+const maxTermQueries = 20
+
+// parseTermQueries reads repeated "term" (word or phrase) and "regex"
+// query parameters.
+func parseTermQueries(c *gin.Context) ([]usecase.TermQuery, error) {
+	var queries []usecase.TermQuery
+	for _, term := range c.QueryArray("term") {
+		if term = strings.TrimSpace(term); term != "" {
+			queries = append(queries, usecase.TermQuery{Term: term})
+		}
+	}
+	for _, expression := range c.QueryArray("regex") {
+		pattern, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %v", expression, err)
+		}
+		queries = append(queries, usecase.TermQuery{Term: expression, Pattern: pattern})
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("at least one term or regex is required")
+	}
+	if len(queries) > maxTermQueries {
+		return nil, fmt.Errorf("at most %d terms can be traced at once", maxTermQueries)
+	}
+	return queries, nil
+}
+
+// parseSearchOptions reads q (words and "quoted phrases"), sender, from and
+// to (2006-01-02, inclusive), timezone, context, limit and offset.
+func parseSearchOptions(c *gin.Context) (usecase.SearchOptions, error) {
+	var opts usecase.SearchOptions
+	phrases, err := usecase.ParseSearchQuery(c.Query("q"))
+	if err != nil {
+		return opts, err
+	}
+	opts.Phrases = phrases
+	opts.Sender = c.Query("sender")
+
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	for key, target := range map[string]*time.Time{"from": &opts.From, "to": &opts.To} {
+		if raw := c.Query(key); raw != "" {
+			if *target, err = time.Parse("2006-01-02", raw); err != nil {
+				return opts, fmt.Errorf("%s must be formatted as YYYY-MM-DD", key)
+			}
+		}
+	}
+	if !opts.From.IsZero() && !opts.To.IsZero() && opts.To.Before(opts.From) {
+		return opts, fmt.Errorf("to must not be before from")
+	}
+
+	if opts.Context, err = parseIntQuery(c, "context", 2); err != nil {
+		return opts, err
+	}
+	if opts.Context > 20 {
+		return opts, fmt.Errorf("context must be at most 20")
+	}
+	if opts.Limit, err = parseIntQuery(c, "limit", 20); err != nil {
+		return opts, err
+	}
+	if opts.Limit < 1 || opts.Limit > 100 {
+		return opts, fmt.Errorf("limit must be between 1 and 100")
+	}
+	if opts.Offset, err = parseIntQuery(c, "offset", 0); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseLanguages reads the optional comma-separated "languages" query parameter.
+func parseLanguages(c *gin.Context) []string {
+	var languages []string
+	for _, language := range strings.Split(c.Query("languages"), ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// parseQuestionOptions reads timezone, sessionGap, languages and unanswered,
+// the number of unanswered questions to list.
+func parseQuestionOptions(c *gin.Context) (usecase.QuestionOptions, error) {
+	var opts usecase.QuestionOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	opts.Languages = parseLanguages(c)
+	if opts.MaxUnanswered, err = parseIntQuery(c, "unanswered", 50); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseRitualOptions reads timezone, languages, dayStart (hour, default 4),
+// asOf and grace.
+func parseRitualOptions(c *gin.Context) (usecase.RitualOptions, error) {
+	var opts usecase.RitualOptions
+	streakOpts, err := parseStreakOptions(c)
+	if err != nil {
+		return opts, err
+	}
+	opts.Location = streakOpts.Location
+	opts.AsOf = streakOpts.AsOf
+	opts.GraceDays = streakOpts.GraceDays
+	opts.Languages = parseLanguages(c)
+	if opts.DayStartHour, err = parseDayStart(c); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseDayStart reads the optional "dayStart" hour, defaulting to 4 so that
+// late-night messages belong to the evening before.
+func parseDayStart(c *gin.Context) (int, error) {
+	hour, err := parseIntQuery(c, "dayStart", 4)
+	if err != nil {
+		return 0, err
+	}
+	if hour > 23 {
+		return 0, fmt.Errorf("dayStart must be an hour between 0 and 23")
+	}
+	return hour, nil
+}
+
+// parseLaughterOptions reads timezone, sessionGap and top.
+func parseLaughterOptions(c *gin.Context) (usecase.LaughterOptions, error) {
+	var opts usecase.LaughterOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	if opts.Top, err = parseIntQuery(c, "top", 10); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseLinkOptions reads timezone and top.
+func parseLinkOptions(c *gin.Context) (usecase.LinkOptions, error) {
+	var opts usecase.LinkOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.Top, err = parseIntQuery(c, "top", 10); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseBurstOptions reads timezone, sessionGap and top.
+func parseBurstOptions(c *gin.Context) (usecase.BurstOptions, error) {
+	var opts usecase.BurstOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	if opts.Top, err = parseIntQuery(c, "top", 10); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseTurnOptions reads timezone, sessionGap and overlap (seconds).
+func parseTurnOptions(c *gin.Context) (usecase.TurnOptions, error) {
+	var opts usecase.TurnOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	seconds, err := parseIntQuery(c, "overlap", int(usecase.DefaultOverlapWindow/time.Second))
+	if err != nil {
+		return opts, err
+	}
+	opts.OverlapWindow = time.Duration(seconds) * time.Second
+	return opts, nil
+}
+
+// parseSilenceOptions reads timezone, longSilence and leftOnRead (hours,
+// defaults 24 and 12), top and asOf, the export time.
+func parseSilenceOptions(c *gin.Context) (usecase.SilenceOptions, error) {
+	var opts usecase.SilenceOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	longSilence, err := parseIntQuery(c, "longSilence", 24)
+	if err != nil {
+		return opts, err
+	}
+	leftOnRead, err := parseIntQuery(c, "leftOnRead", 12)
+	if err != nil {
+		return opts, err
+	}
+	if longSilence == 0 || leftOnRead == 0 {
+		return opts, fmt.Errorf("longSilence and leftOnRead must be at least 1 hour")
+	}
+	opts.LongSilence = time.Duration(longSilence) * time.Hour
+	opts.LeftOnRead = time.Duration(leftOnRead) * time.Hour
+	if opts.Top, err = parseIntQuery(c, "top", 10); err != nil {
+		return opts, err
+	}
+	if opts.AsOf, err = parseAsOf(c, opts.Location); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseChronotypeOptions reads timezone and dayStart.
+func parseChronotypeOptions(c *gin.Context) (usecase.ChronotypeOptions, error) {
+	var opts usecase.ChronotypeOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.DayStartHour, err = parseDayStart(c); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseAnomalyOptions reads timezone, granularity (day or week), weekStart,
+// neighbors, threshold and sessionGap.
+func parseAnomalyOptions(c *gin.Context) (usecase.AnomalyOptions, error) {
+	var opts usecase.AnomalyOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	opts.Granularity = c.DefaultQuery("granularity", usecase.GranularityDay)
+	if opts.Granularity != usecase.GranularityDay && opts.Granularity != usecase.GranularityWeek {
+		return opts, fmt.Errorf("granularity must be day or week")
+	}
+	if opts.WeekStart, err = parseWeekStart(c); err != nil {
+		return opts, err
+	}
+	if opts.Neighbors, err = parseIntQuery(c, "neighbors", 4); err != nil {
+		return opts, err
+	}
+	if opts.Neighbors < 2 {
+		return opts, fmt.Errorf("neighbors must be at least 2")
+	}
+	opts.Threshold = 3.5
+	if raw := c.Query("threshold"); raw != "" {
+		if opts.Threshold, err = strconv.ParseFloat(raw, 64); err != nil || opts.Threshold <= 0 {
+			return opts, fmt.Errorf("threshold must be a positive number")
+		}
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseForecastOptions reads timezone, weeks (1-52, default 4), history
+// (days, default 180), confidence (0.8, 0.9 or 0.95) and target.
+func parseForecastOptions(c *gin.Context) (usecase.ForecastOptions, error) {
+	var opts usecase.ForecastOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.Weeks, err = parseIntQuery(c, "weeks", 4); err != nil {
+		return opts, err
+	}
+	if opts.Weeks < 1 || opts.Weeks > 52 {
+		return opts, fmt.Errorf("weeks must be between 1 and 52")
+	}
+	if opts.HistoryDays, err = parseIntQuery(c, "history", 180); err != nil {
+		return opts, err
+	}
+	if opts.HistoryDays < 14 {
+		return opts, fmt.Errorf("history must be at least 14 days")
+	}
+	opts.Confidence = 0.95
+	if raw := c.Query("confidence"); raw != "" {
+		if opts.Confidence, err = strconv.ParseFloat(raw, 64); err != nil {
+			return opts, fmt.Errorf("confidence must be 0.8, 0.9 or 0.95")
+		}
+	}
+	if opts.Target, err = parseIntQuery(c, "target", 0); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseWrappedOptions reads timezone, year, sessionGap and top. A missing
+// year summarises the year of the latest message.
+func parseWrappedOptions(c *gin.Context) (usecase.WrappedOptions, error) {
+	var opts usecase.WrappedOptions
+	var err error
+	if opts.Location, err = parseLocation(c); err != nil {
+		return opts, err
+	}
+	if opts.Year, err = parseIntQuery(c, "year", 0); err != nil {
+		return opts, err
+	}
+	if opts.SessionGap, err = parseSessionGap(c); err != nil {
+		return opts, err
+	}
+	if opts.Top, err = parseIntQuery(c, "top", 10); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
@@ -0,0 +1,29 @@
+// internal/domain/anomaly.go
+package domain
+
+// AnomalyContext helps explain an anomalous period: how many messages each
+// participant sent, the words that stood out, and its longest messages.
+type AnomalyContext struct {
+	Messages map[string]int `json:"messages"`
+	TopWords []string       `json:"topWords"`
+	Samples  []string       `json:"samples"`
+}
+
+// Anomaly is a day or week whose value of Metric sits far from what its
+// neighbouring periods predict. Score is the robust z-score, positive for a
+// spike and negative for a drop.
+type Anomaly struct {
+	Period   string         `json:"period"`
+	Metric   string         `json:"metric"`
+	Kind     string         `json:"kind"`
+	Value    float64        `json:"value"`
+	Expected float64        `json:"expected"`
+	Score    float64        `json:"score"`
+	Context  AnomalyContext `json:"context"`
+}
+
+type AnomalyReport struct {
+	Granularity string    `json:"granularity"`
+	Threshold   float64   `json:"threshold"`
+	Anomalies   []Anomaly `json:"anomalies"`
+}
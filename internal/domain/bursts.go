@@ -0,0 +1,32 @@
+// internal/domain/bursts.go
+package domain
+
+// BurstStats compares one participant's messages with their turns, a turn
+// being every message they send before the other person replies or a long
+// silence falls. DoubleTexts counts turns of two or more messages;
+// Distribution buckets turns by length.
+type BurstStats struct {
+	Messages        int            `json:"messages"`
+	Turns           int            `json:"turns"`
+	MessagesPerTurn float64        `json:"messagesPerTurn"`
+	WordsPerTurn    float64        `json:"wordsPerTurn"`
+	DoubleTexts     int            `json:"doubleTexts"`
+	DoubleTextRate  float64        `json:"doubleTextRate"`
+	LongestBurst    int            `json:"longestBurst"`
+	Distribution    map[string]int `json:"distribution"`
+}
+
+// Monologue is one long turn.
+type Monologue struct {
+	From            string `json:"from"`
+	Start           string `json:"start"`
+	End             string `json:"end"`
+	Messages        int    `json:"messages"`
+	Words           int    `json:"words"`
+	DurationSeconds int    `json:"durationSeconds"`
+}
+
+type BurstReport struct {
+	People     map[string]BurstStats `json:"people"`
+	Monologues []Monologue           `json:"monologues"`
+}
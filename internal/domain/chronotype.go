@@ -0,0 +1,42 @@
+// internal/domain/chronotype.go
+package domain
+
+// ClockWindow is a stretch of the day, possibly across midnight, with times
+// formatted as 15:04.
+type ClockWindow struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Hours float64 `json:"hours"`
+}
+
+// DailyRhythm is the typical time of the first and last message of a day.
+type DailyRhythm struct {
+	FirstMessage string `json:"firstMessage"`
+	LastMessage  string `json:"lastMessage"`
+}
+
+// Chronotype profiles when one participant is awake and writing. Hourly is
+// the share of their messages sent in each hour. Sleep runs from the typical
+// last message to the typical first message, and WeekendShiftMinutes is how
+// much later (positive) or earlier its midpoint falls on weekends. Type is
+// "early bird", "intermediate" or "night owl".
+type Chronotype struct {
+	Messages            int         `json:"messages"`
+	Hourly              []float64   `json:"hourly"`
+	ActiveWindow        ClockWindow `json:"activeWindow"`
+	Rhythm              DailyRhythm `json:"rhythm"`
+	Weekday             DailyRhythm `json:"weekday"`
+	Weekend             DailyRhythm `json:"weekend"`
+	Sleep               ClockWindow `json:"sleep"`
+	WeekendShiftMinutes int         `json:"weekendShiftMinutes"`
+	Type                string      `json:"type"`
+}
+
+// ChronotypeReport compares the participants' daily rhythms. OverlapHours is
+// how many hours their active windows share, and Compatibility how much
+// their hourly activity overlaps, from 0 (never awake together) to 1.
+type ChronotypeReport struct {
+	People        map[string]Chronotype `json:"people"`
+	OverlapHours  int                   `json:"overlapHours"`
+	Compatibility float64               `json:"compatibility"`
+}
@@ -0,0 +1,25 @@
+// internal/domain/entities.go
+package domain
+
+// EntityUsage counts one participant's text entities by type. FormattedMessages
+// is the number of their messages using bold, italic, code or similar styling.
+type EntityUsage struct {
+	Counts            map[string]int `json:"counts"`
+	FormattedMessages int            `json:"formattedMessages"`
+	FormattingShare   float64        `json:"formattingShare"`
+}
+
+// EntityCount is a hashtag or mention with how often each participant used it.
+type EntityCount struct {
+	Text   string         `json:"text"`
+	Total  int            `json:"total"`
+	Counts map[string]int `json:"counts"`
+}
+
+// EntityReport breaks down entity usage per participant. Mentions lists, per
+// participant, whom they tagged most; phone numbers and emails are only counted.
+type EntityReport struct {
+	People      map[string]EntityUsage   `json:"people"`
+	Mentions    map[string][]EntityCount `json:"mentions"`
+	TopHashtags []EntityCount            `json:"topHashtags"`
+}
@@ -0,0 +1,35 @@
+// internal/domain/forecast.go
+package domain
+
+// ForecastPoint is the projected message count for a day or a week starting
+// on Date, with the bounds of the prediction interval.
+type ForecastPoint struct {
+	Date     string  `json:"date"`
+	Expected float64 `json:"expected"`
+	Lower    float64 `json:"lower"`
+	Upper    float64 `json:"upper"`
+}
+
+// ForecastMilestone is the projected day the chat reaches Target messages.
+// Date is empty when the projection does not get there within
+// milestoneHorizonDays, five years.
+type ForecastMilestone struct {
+	Target   int    `json:"target"`
+	Current  int    `json:"current"`
+	Date     string `json:"date"`
+	DaysAway int    `json:"daysAway"`
+}
+
+// Forecast projects message volume from a linear trend plus weekday effects
+// fitted to the last HistoryDays days. TrendPerDay is how many messages per
+// day the volume grows or shrinks each day; Weekday holds each weekday's
+// offset from the trend.
+type Forecast struct {
+	HistoryDays int                `json:"historyDays"`
+	Confidence  float64            `json:"confidence"`
+	TrendPerDay float64            `json:"trendPerDay"`
+	Weekday     map[string]float64 `json:"weekday"`
+	Daily       []ForecastPoint    `json:"daily"`
+	Weekly      []ForecastPoint    `json:"weekly"`
+	Milestone   ForecastMilestone  `json:"milestone"`
+}
@@ -0,0 +1,19 @@
+// internal/domain/heatmap.go
+package domain
+
+// Heatmap counts messages per weekday and hour. Days lists the weekday names
+// in row order, starting from the requested first day of the week; each grid
+// is 7 rows by 24 hour columns, keyed by participant name or "overall".
+type Heatmap struct {
+	Days      []string               `json:"days"`
+	Normalize string                 `json:"normalize"`
+	Grids     map[string][][]float64 `json:"grids"`
+	Peaks     map[string]HeatmapPeak `json:"peaks"`
+}
+
+// HeatmapPeak is the busiest weekday and hour for one participant.
+type HeatmapPeak struct {
+	Day      string `json:"day"`
+	Hour     int    `json:"hour"`
+	Messages int    `json:"messages"`
+}
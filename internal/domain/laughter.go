@@ -0,0 +1,37 @@
+// internal/domain/laughter.go
+package domain
+
+// LaughterStats describes one participant's laughter. MadeLaugh counts the
+// laughs their messages drew from the other participant.
+type LaughterStats struct {
+	Messages  int     `json:"messages"`
+	Laughs    int     `json:"laughs"`
+	LaughRate float64 `json:"laughRate"`
+	MadeLaugh int     `json:"madeLaugh"`
+}
+
+// FunnyMessage is a message that made the other participant laugh. Laughs is
+// the number of participants who laughed at it and Intensity the laughter
+// tokens in all their laughing replies.
+type FunnyMessage struct {
+	ID        int    `json:"id"`
+	From      string `json:"from"`
+	Date      string `json:"date"`
+	Text      string `json:"text"`
+	Laughs    int    `json:"laughs"`
+	Intensity int    `json:"intensity"`
+}
+
+// LaughterTimeline holds the monthly laughing messages and their share of
+// all messages per participant.
+type LaughterTimeline struct {
+	Periods []string             `json:"periods"`
+	Laughs  map[string][]int     `json:"laughs"`
+	Rate    map[string][]float64 `json:"rate"`
+}
+
+type LaughterReport struct {
+	People   map[string]LaughterStats `json:"people"`
+	Funniest []FunnyMessage           `json:"funniest"`
+	Timeline LaughterTimeline         `json:"timeline"`
+}
@@ -0,0 +1,31 @@
+// internal/domain/lexical_diversity.go
+package domain
+
+// LexicalStats describes how varied one participant's vocabulary is.
+// MTLD and MSTTR do not shrink as someone writes more, so they can be
+// compared between a chatty and a quiet participant; TypeTokenRatio can not.
+type LexicalStats struct {
+	Tokens         int     `json:"tokens"`
+	VocabularySize int     `json:"vocabularySize"`
+	TypeTokenRatio float64 `json:"typeTokenRatio"`
+	MSTTR          float64 `json:"msttr"`
+	MTLD           float64 `json:"mtld"`
+	Hapax          int     `json:"hapax"`
+	HapaxRatio     float64 `json:"hapaxRatio"`
+}
+
+// VocabularyGrowth holds, per participant and month, how many words were used
+// for the first time and the vocabulary size reached by the end of the month.
+type VocabularyGrowth struct {
+	Periods        []string         `json:"periods"`
+	NewWords       map[string][]int `json:"newWords"`
+	VocabularySize map[string][]int `json:"vocabularySize"`
+}
+
+// LexicalDiversityReport compares the participants' vocabularies. Richest is
+// the participant with the higher MTLD, empty when it is a tie.
+type LexicalDiversityReport struct {
+	People  map[string]LexicalStats `json:"people"`
+	Richest string                  `json:"richest"`
+	Growth  VocabularyGrowth        `json:"growth"`
+}
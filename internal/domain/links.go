@@ -0,0 +1,39 @@
+// internal/domain/links.go
+package domain
+
+// LinkStats counts the links one participant shared, by category.
+type LinkStats struct {
+	Links       int            `json:"links"`
+	UniqueLinks int            `json:"uniqueLinks"`
+	Categories  map[string]int `json:"categories"`
+}
+
+// DomainCount is a domain with its category and how often each participant linked to it.
+type DomainCount struct {
+	Domain   string         `json:"domain"`
+	Category string         `json:"category"`
+	Total    int            `json:"total"`
+	Counts   map[string]int `json:"counts"`
+}
+
+// RepeatedLink is a normalized URL shared more than once.
+type RepeatedLink struct {
+	URL         string         `json:"url"`
+	Total       int            `json:"total"`
+	Counts      map[string]int `json:"counts"`
+	FirstShared string         `json:"firstShared"`
+	LastShared  string         `json:"lastShared"`
+}
+
+// LinkTimeline holds the links shared per participant and month.
+type LinkTimeline struct {
+	Periods []string         `json:"periods"`
+	Links   map[string][]int `json:"links"`
+}
+
+type LinkReport struct {
+	People     map[string]LinkStats `json:"people"`
+	TopDomains []DomainCount        `json:"topDomains"`
+	Repeated   []RepeatedLink       `json:"repeated"`
+	Timeline   LinkTimeline         `json:"timeline"`
+}
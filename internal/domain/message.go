@@ -20,9 +20,18 @@ type Message struct {
 	Text             interface{}  `json:"text" bson:"text"`
 	ReplyToMessageID int          `json:"reply_to_message_id,omitempty" bson:"reply_to_message_id,omitempty"`
 	TextEntities     []TextEntity `json:"text_entities" bson:"text_entities"`
+	Photo            string       `json:"photo,omitempty" bson:"photo,omitempty"`
+	File             string       `json:"file,omitempty" bson:"file,omitempty"`
+	MediaType        string       `json:"media_type,omitempty" bson:"media_type,omitempty"`
+	MimeType         string       `json:"mime_type,omitempty" bson:"mime_type,omitempty"`
+	StickerEmoji     string       `json:"sticker_emoji,omitempty" bson:"sticker_emoji,omitempty"`
+	DurationSeconds  int          `json:"duration_seconds,omitempty" bson:"duration_seconds,omitempty"`
+	Action           string       `json:"action,omitempty" bson:"action,omitempty"`
 }
 
 type TextEntity struct {
 	Type string `json:"type" bson:"type"`
 	Text string `json:"text" bson:"text"`
+	// Href is the target of a "text_link" entity, whose Text is only the label.
+	Href string `json:"href,omitempty" bson:"href,omitempty"`
 }
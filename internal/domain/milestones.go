@@ -0,0 +1,22 @@
+// internal/domain/milestones.go
+package domain
+
+// MilestonePhrases is the serialisable set of phrases whose first use is a
+// milestone, keyed by milestone name such as "i love you".
+type MilestonePhrases map[string][]string
+
+// Milestone is one moment in the chat's history. Date is always 2006-01-02;
+// Time is 15:04:05 for moments tied to a message and empty for whole days.
+// From and MessageID identify the message when there is one; Value carries
+// the number behind the milestone (message count, years, messages that day
+// or minutes of conversation), and Upcoming marks a future anniversary.
+type Milestone struct {
+	Kind      string `json:"kind"`
+	Title     string `json:"title"`
+	Date      string `json:"date"`
+	Time      string `json:"time,omitempty"`
+	From      string `json:"from,omitempty"`
+	MessageID int    `json:"messageId,omitempty"`
+	Value     int    `json:"value,omitempty"`
+	Upcoming  bool   `json:"upcoming,omitempty"`
+}
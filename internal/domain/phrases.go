@@ -0,0 +1,34 @@
+// internal/domain/phrases.go
+package domain
+
+// PhraseStat is how often a phrase was used and how strongly its words
+// belong together. Score is the normalized PMI of the phrase, from -1
+// (never together) through 0 (independent) to 1 (only ever together).
+type PhraseStat struct {
+	Phrase string         `json:"phrase"`
+	Count  int            `json:"count"`
+	Score  float64        `json:"score"`
+	Counts map[string]int `json:"counts"`
+}
+
+// Catchphrase is a phrase one participant keeps coming back to. When the
+// other participant picked it up too, MirroredBy and FirstMirrored say who
+// and when.
+type Catchphrase struct {
+	Phrase        string         `json:"phrase"`
+	Owner         string         `json:"owner"`
+	Counts        map[string]int `json:"counts"`
+	Days          int            `json:"days"`
+	FirstUsed     string         `json:"firstUsed"`
+	LastUsed      string         `json:"lastUsed"`
+	MirroredBy    string         `json:"mirroredBy,omitempty"`
+	FirstMirrored string         `json:"firstMirrored,omitempty"`
+}
+
+// PhraseReport holds the top bigrams and trigrams per participant and the
+// detected catchphrases.
+type PhraseReport struct {
+	Bigrams      map[string][]PhraseStat `json:"bigrams"`
+	Trigrams     map[string][]PhraseStat `json:"trigrams"`
+	Catchphrases []Catchphrase           `json:"catchphrases"`
+}
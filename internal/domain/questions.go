@@ -0,0 +1,39 @@
+// internal/domain/questions.go
+package domain
+
+// QuestionRules is the serialisable rule set that marks messages as questions
+// in one language: a message is a question when it contains one of Marks,
+// starts with one of StartWords, or starts with one of AuxiliaryWords
+// followed by one of Subjects ("do you ..." but not "do it now").
+type QuestionRules struct {
+	Marks          []string `json:"marks"`
+	StartWords     []string `json:"startWords"`
+	AuxiliaryWords []string `json:"auxiliaryWords,omitempty"`
+	Subjects       []string `json:"subjects,omitempty"`
+}
+
+// QuestionStats describes the questions one participant asked and how the
+// other participant responded to them.
+type QuestionStats struct {
+	Questions            int     `json:"questions"`
+	QuestionShare        float64 `json:"questionShare"`
+	Answered             int     `json:"answered"`
+	AnswerRate           float64 `json:"answerRate"`
+	AverageAnswerSeconds float64 `json:"averageAnswerSeconds"`
+}
+
+// Question is a message classified as a question.
+type Question struct {
+	ID   int    `json:"id"`
+	From string `json:"from"`
+	Date string `json:"date"`
+	Text string `json:"text"`
+}
+
+// QuestionReport holds per-person question statistics, keyed by the asker,
+// and the questions that got no reply within their session, newest first.
+type QuestionReport struct {
+	Languages  []string                 `json:"languages"`
+	People     map[string]QuestionStats `json:"people"`
+	Unanswered []Question               `json:"unanswered"`
+}
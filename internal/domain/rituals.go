@@ -0,0 +1,34 @@
+// internal/domain/rituals.go
+package domain
+
+// RitualPatterns is the serialisable set of phrases for one language, keyed
+// by ritual name such as "morning" or "night".
+type RitualPatterns map[string][]string
+
+// RitualStats describes how one participant keeps a ritual. FirstDays counts
+// the days they said it before the other person, Consistency is the share of
+// their active days on which they said it, and TypicalTime is the median time
+// of day, formatted as 15:04.
+type RitualStats struct {
+	Messages    int     `json:"messages"`
+	Days        int     `json:"days"`
+	FirstDays   int     `json:"firstDays"`
+	Consistency float64 `json:"consistency"`
+	TypicalTime string  `json:"typicalTime"`
+}
+
+// Ritual summarises one ritual. Longest and Current are keyed by participant
+// plus "overall" for days on which either person performed it.
+type Ritual struct {
+	Name       string                 `json:"name"`
+	Days       int                    `json:"days"`
+	MutualDays int                    `json:"mutualDays"`
+	People     map[string]RitualStats `json:"people"`
+	Longest    map[string]Streak      `json:"longest"`
+	Current    map[string]Streak      `json:"current"`
+}
+
+type RitualReport struct {
+	Languages []string `json:"languages"`
+	Rituals   []Ritual `json:"rituals"`
+}
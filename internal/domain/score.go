@@ -0,0 +1,29 @@
+// internal/domain/score.go
+package domain
+
+// ScoreWeights maps a relationship score component name to its weight.
+type ScoreWeights map[string]float64
+
+// ScoreComponent explains how one input moved the relationship score.
+// Contribution is signed: penalties are negative and bonuses positive.
+type ScoreComponent struct {
+	Name         string  `json:"name"`
+	Input        float64 `json:"input"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+type RelationshipScore struct {
+	Score      float64          `json:"score"`
+	Components []ScoreComponent `json:"components"`
+}
+
+// ScorePoint is the relationship score for the window ending at PeriodEnd.
+// PeriodStart and PeriodEnd are inclusive dates formatted as 2006-01-02.
+type ScorePoint struct {
+	PeriodStart string           `json:"periodStart"`
+	PeriodEnd   string           `json:"periodEnd"`
+	Messages    int              `json:"messages"`
+	Score       float64          `json:"score"`
+	Components  []ScoreComponent `json:"components"`
+}
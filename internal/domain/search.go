@@ -0,0 +1,33 @@
+// internal/domain/search.go
+package domain
+
+// Posting lists where a term occurs inside one message: Message is the
+// index into Chat.Messages and Positions are word offsets within it.
+type Posting struct {
+	Message   int   `json:"message" bson:"m"`
+	Positions []int `json:"positions" bson:"p"`
+}
+
+// SearchIndex is an inverted index from normalized term to postings,
+// built once when a chat is uploaded.
+type SearchIndex map[string][]Posting
+
+// SearchMessage is a message as shown in search results.
+type SearchMessage struct {
+	ID   int    `json:"id"`
+	From string `json:"from"`
+	Date string `json:"date"`
+	Text string `json:"text"`
+}
+
+// SearchHit is a matching message with the messages around it.
+type SearchHit struct {
+	Message SearchMessage   `json:"message"`
+	Before  []SearchMessage `json:"before"`
+	After   []SearchMessage `json:"after"`
+}
+
+type SearchResult struct {
+	Total int         `json:"total"`
+	Hits  []SearchHit `json:"hits"`
+}
@@ -0,0 +1,36 @@
+// internal/domain/sentiment.go
+package domain
+
+// LexiconData is the serialisable form of a sentiment lexicon. Word scores
+// use the usual -4 (very negative) to +4 (very positive) valence scale;
+// a negator flips the score of the word that follows it.
+type LexiconData struct {
+	Words    map[string]float64 `json:"words"`
+	Negators []string           `json:"negators"`
+}
+
+// MessageSentiment is the polarity of one message, from -1 to 1.
+type MessageSentiment struct {
+	ID       int     `json:"id"`
+	From     string  `json:"from"`
+	Date     string  `json:"date"`
+	Polarity float64 `json:"polarity"`
+}
+
+// DailyMood is the average polarity of the scored messages sent on Date,
+// keyed by participant name and "overall".
+type DailyMood struct {
+	Date     string             `json:"date"`
+	Messages int                `json:"messages"`
+	Average  map[string]float64 `json:"average"`
+}
+
+type SentimentReport struct {
+	Languages        []string           `json:"languages"`
+	Averages         map[string]float64 `json:"averages"`
+	ScoredMessages   map[string]int     `json:"scoredMessages"`
+	Daily            []DailyMood        `json:"daily"`
+	MostPositiveDays []DailyMood        `json:"mostPositiveDays"`
+	MostNegativeDays []DailyMood        `json:"mostNegativeDays"`
+	Messages         []MessageSentiment `json:"messages,omitempty"`
+}
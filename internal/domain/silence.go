@@ -0,0 +1,31 @@
+// internal/domain/silence.go
+package domain
+
+// Silence is a gap between two consecutive messages, or after the last one.
+// LastFrom sent the message before it and BrokenBy the message that ended
+// it; BrokenBy is empty for the silence that still lasts.
+type Silence struct {
+	Start    string  `json:"start"`
+	End      string  `json:"end"`
+	Hours    float64 `json:"hours"`
+	LastFrom string  `json:"lastFrom"`
+	BrokenBy string  `json:"brokenBy,omitempty"`
+}
+
+// SilenceStats describes one participant's part in long silences. LeftOnRead
+// counts the times their message went unanswered for longer than the
+// threshold; LeftOtherOnRead the times they did that to the other person.
+type SilenceStats struct {
+	Broken          int `json:"broken"`
+	LeftOnRead      int `json:"leftOnRead"`
+	LeftOtherOnRead int `json:"leftOtherOnRead"`
+}
+
+// SilenceReport lists the longest silences and, per month, how many silences
+// longer than the threshold began in it.
+type SilenceReport struct {
+	Longest []Silence               `json:"longest"`
+	People  map[string]SilenceStats `json:"people"`
+	Periods []string                `json:"periods"`
+	Monthly []int                   `json:"monthly"`
+}
@@ -0,0 +1,18 @@
+// internal/domain/streak.go
+package domain
+
+// Streak is a run of consecutive calendar days with at least one message.
+// Start and End are formatted as 2006-01-02 in the timezone the streak was computed in.
+type Streak struct {
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	Length int    `json:"length"`
+}
+
+// StreakReport groups every streak view for a chat. Each map is keyed by
+// participant name plus "overall" for days where either person wrote.
+type StreakReport struct {
+	Longest map[string]Streak   `json:"longest"`
+	Current map[string]Streak   `json:"current"`
+	Streaks map[string][]Streak `json:"streaks"`
+}
@@ -0,0 +1,29 @@
+// internal/domain/term_timeline.go
+package domain
+
+// TermOccurrence records when and by whom a term was used.
+type TermOccurrence struct {
+	Date      string `json:"date"`
+	From      string `json:"from"`
+	MessageID int    `json:"messageId"`
+}
+
+// TermUsage describes how one searched word, phrase or pattern was used.
+// Monthly is aligned with TermTimeline.Periods. FirstUse and LastUse are
+// nil when the term never appears.
+type TermUsage struct {
+	Term     string          `json:"term"`
+	Regex    bool            `json:"regex"`
+	Total    int             `json:"total"`
+	Counts   map[string]int  `json:"counts"`
+	FirstUse *TermOccurrence `json:"firstUse"`
+	LastUse  *TermOccurrence `json:"lastUse"`
+	Monthly  []int           `json:"monthly"`
+}
+
+// TermTimeline answers "when did we start saying X?" for several terms.
+// Periods holds the first day of every month covered by the chat.
+type TermTimeline struct {
+	Periods []string    `json:"periods"`
+	Terms   []TermUsage `json:"terms"`
+}
@@ -0,0 +1,20 @@
+// internal/domain/timeseries.go
+package domain
+
+// Series holds one value per period. RollingAverage and Cumulative are only
+// filled in when requested.
+type Series struct {
+	Values         []int     `json:"values"`
+	RollingAverage []float64 `json:"rollingAverage,omitempty"`
+	Cumulative     []int     `json:"cumulative,omitempty"`
+}
+
+// TimeSeries is an ordered, gap-filled series per metric and participant.
+// Periods holds the first day of each period as 2006-01-02; Series is keyed
+// by metric ("messages", "words", "characters", "media") and then by
+// participant name or "overall".
+type TimeSeries struct {
+	Granularity string                       `json:"granularity"`
+	Periods     []string                     `json:"periods"`
+	Series      map[string]map[string]Series `json:"series"`
+}
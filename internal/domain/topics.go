@@ -0,0 +1,30 @@
+// internal/domain/topics.go
+package domain
+
+type TopicTerm struct {
+	Term   string  `json:"term"`
+	Weight float64 `json:"weight"`
+}
+
+// Topic is one theme found by the topic model. Prevalence is its share of
+// all words in the chat, Drivers splits that share between participants, and
+// Timeline holds its average share of each period in TopicReport.Periods.
+type Topic struct {
+	ID         int                `json:"id"`
+	Terms      []TopicTerm        `json:"terms"`
+	Prevalence float64            `json:"prevalence"`
+	Drivers    map[string]float64 `json:"drivers"`
+	Timeline   []float64          `json:"timeline"`
+}
+
+// TopicReport is the result of topic modeling. Unit says whether each
+// document was a conversation session or a calendar day; Iterations is how
+// many sampling sweeps were run, which is fewer than requested for large
+// chats; Periods holds the first day of each month covered by the chat.
+type TopicReport struct {
+	Unit       string   `json:"unit"`
+	Documents  int      `json:"documents"`
+	Iterations int      `json:"iterations"`
+	Periods    []string `json:"periods"`
+	Topics     []Topic  `json:"topics"`
+}
@@ -0,0 +1,28 @@
+// internal/domain/turn_taking.go
+package domain
+
+// TurnStats describes one participant's turns: how many messages and words
+// each turn holds, and how often they cut into the other person's burst.
+type TurnStats struct {
+	Turns         int          `json:"turns"`
+	Messages      Distribution `json:"messages"`
+	Words         Distribution `json:"words"`
+	Interruptions int          `json:"interruptions"`
+}
+
+// BalancePoint is the turn-taking balance of one month. Index is the smaller
+// participant's share of words divided by the larger one's: 1 when both say
+// as much, near 0 when one person does all the talking.
+type BalancePoint struct {
+	Period string         `json:"period"`
+	Turns  map[string]int `json:"turns"`
+	Words  map[string]int `json:"words"`
+	Index  float64        `json:"index"`
+}
+
+type TurnTakingReport struct {
+	Sessions        int                  `json:"sessions"`
+	TurnsPerSession Distribution         `json:"turnsPerSession"`
+	People          map[string]TurnStats `json:"people"`
+	Balance         []BalancePoint       `json:"balance"`
+}
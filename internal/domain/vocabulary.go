@@ -0,0 +1,17 @@
+// internal/domain/vocabulary.go
+package domain
+
+// TermScore is a word or phrase with the score it was ranked by and how
+// often each participant used it.
+type TermScore struct {
+	Term   string         `json:"term"`
+	Score  float64        `json:"score"`
+	Counts map[string]int `json:"counts"`
+}
+
+// VocabularyReport lists the terms that set each participant apart
+// (keyed by name) and the terms both of them genuinely share.
+type VocabularyReport struct {
+	Distinctive map[string][]TermScore `json:"distinctive"`
+	Shared      []TermScore            `json:"shared"`
+}
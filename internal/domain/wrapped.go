@@ -0,0 +1,41 @@
+// internal/domain/wrapped.go
+package domain
+
+// PeriodCount is a month, date, weekday or hour with its message count.
+type PeriodCount struct {
+	Period   string `json:"period"`
+	Messages int    `json:"messages"`
+}
+
+// CallSummary totals the voice and video calls of a period.
+type CallSummary struct {
+	Calls        int `json:"calls"`
+	TotalSeconds int `json:"totalSeconds"`
+}
+
+// Conversation is one session of messages without a long silence.
+type Conversation struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Messages int    `json:"messages"`
+	Minutes  int    `json:"minutes"`
+}
+
+// Wrapped is the year-in-review summary of a chat. Messages and
+// LongestStreak are keyed by participant plus "overall"; MedianReplySeconds
+// is keyed by the participant replying.
+type Wrapped struct {
+	Year                int                `json:"year"`
+	Messages            map[string]int     `json:"messages"`
+	TopWords            []EntityCount      `json:"topWords"`
+	TopEmoji            []EntityCount      `json:"topEmoji"`
+	BusiestMonth        PeriodCount        `json:"busiestMonth"`
+	BusiestDate         PeriodCount        `json:"busiestDate"`
+	BusiestWeekday      PeriodCount        `json:"busiestWeekday"`
+	BusiestHour         PeriodCount        `json:"busiestHour"`
+	LongestStreak       map[string]Streak  `json:"longestStreak"`
+	MedianReplySeconds  map[string]float64 `json:"medianReplySeconds"`
+	FastestReplier      string             `json:"fastestReplier"`
+	Calls               CallSummary        `json:"calls"`
+	BiggestConversation Conversation       `json:"biggestConversation"`
+}
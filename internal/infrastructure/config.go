@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+// LoadScoreProfiles reads named relationship score weight profiles from a
+// JSON file shaped like {"strict": {"replyTime": 0.5}}. Profiles only need
+// to list the weights they change. An empty path means no profiles.
+func LoadScoreProfiles(path string) (map[string]domain.ScoreWeights, error) {
+	var profiles map[string]domain.ScoreWeights
+	if err := loadJSON(path, &profiles); err != nil {
+		return nil, fmt.Errorf("score profiles: %v", err)
+	}
+	return profiles, nil
+}
+
+// LoadLexicons reads extra sentiment lexicons keyed by language, shaped like
+// {"fr": {"words": {"bien": 2}, "negators": ["pas"]}}. An empty path means none.
+func LoadLexicons(path string) (map[string]domain.LexiconData, error) {
+	var lexicons map[string]domain.LexiconData
+	if err := loadJSON(path, &lexicons); err != nil {
+		return nil, fmt.Errorf("sentiment lexicons: %v", err)
+	}
+	return lexicons, nil
+}
+
+// LoadQuestionRules reads extra question rules keyed by language, shaped like
+// {"de": {"marks": ["?"], "startWords": ["wer", "was", "wann"]}}. An empty path means none.
+func LoadQuestionRules(path string) (map[string]domain.QuestionRules, error) {
+	var rules map[string]domain.QuestionRules
+	if err := loadJSON(path, &rules); err != nil {
+		return nil, fmt.Errorf("question rules: %v", err)
+	}
+	return rules, nil
+}
+
+// LoadRitualPatterns reads extra ritual phrases keyed by language and ritual,
+// shaped like {"de": {"morning": ["guten morgen"], "night": ["gute nacht"]}}.
+// An empty path means none.
+func LoadRitualPatterns(path string) (map[string]domain.RitualPatterns, error) {
+	var patterns map[string]domain.RitualPatterns
+	if err := loadJSON(path, &patterns); err != nil {
+		return nil, fmt.Errorf("ritual patterns: %v", err)
+	}
+	return patterns, nil
+}
+
+// LoadMilestonePhrases reads extra milestone phrases keyed by milestone name,
+// shaped like {"first date": ["see you tonight"]}. An empty path means none.
+func LoadMilestonePhrases(path string) (domain.MilestonePhrases, error) {
+	var phrases domain.MilestonePhrases
+	if err := loadJSON(path, &phrases); err != nil {
+		return nil, fmt.Errorf("milestone phrases: %v", err)
+	}
+	return phrases, nil
+}
+
+// loadJSON decodes the file at path into v, leaving v untouched when path is empty.
+func loadJSON(path string, v interface{}) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return nil
+}
@@ -3,15 +3,40 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"time"
 
+	"telegram-chat-analyzer/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrNotFound is returned when a chat does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrNotIndexed is returned by FindPostings when no search index is stored for
+// a chat, such as chats saved before indexing existed or chats without words.
+var ErrNotIndexed = errors.New("not indexed")
+
+// searchIndexBatchSize bounds how many term documents go into one BulkWrite.
+const searchIndexBatchSize = 1000
+
+// postingsPerChunk bounds the postings kept in one term document, so that a
+// very common term of a long chat stays well below the 16MB document limit.
+const postingsPerChunk = 10000
+
 type MongoRepository interface {
-	SaveProcessedData(ctx context.Context, collection string, data interface{}) error
+	SaveProcessedData(ctx context.Context, collection string, data interface{}) (string, error)
+	FindChat(ctx context.Context, collection string, chatID string) (domain.Chat, error)
+	SaveSearchIndex(ctx context.Context, collection string, chatID string, index domain.SearchIndex) error
+	FindPostings(ctx context.Context, collection string, chatID string, terms []string) (domain.SearchIndex, error)
+	FindMessages(ctx context.Context, collection string, chatID string, positions []int) (map[int]domain.Message, error)
+	DeleteChat(ctx context.Context, collection string, chatID string) error
 }
 
 type mongoRepository struct {
@@ -19,6 +44,16 @@ type mongoRepository struct {
 	dbName string
 }
 
+// searchTermDocument stores one chunk of the postings of one term of one
+// chat. Keeping documents per term lets a query fetch only the terms it
+// needs; Chunk numbers a term's documents in message order.
+type searchTermDocument struct {
+	ChatID   string           `bson:"chat_id"`
+	Term     string           `bson:"term"`
+	Chunk    int              `bson:"chunk"`
+	Postings []domain.Posting `bson:"postings"`
+}
+
 func NewMongoRepository(connectionString, dbName string) (MongoRepository, error) {
 	client, err := mongo.NewClient(options.Client().ApplyURI(connectionString))
 	if err != nil {
@@ -36,15 +71,222 @@ func NewMongoRepository(connectionString, dbName string) (MongoRepository, error
 	return &mongoRepository{client: client, dbName: dbName}, nil
 }
 
-func (r *mongoRepository) SaveProcessedData(ctx context.Context, collection string, data interface{}) error {
+// SaveProcessedData inserts data and returns the hex id of the new document.
+func (r *mongoRepository) SaveProcessedData(ctx context.Context, collection string, data interface{}) (string, error) {
 	coll := r.client.Database(r.dbName).Collection(collection)
 
-	_, err := coll.InsertOne(ctx, data)
+	result, err := coll.InsertOne(ctx, data)
 	if err != nil {
 		log.Printf("Failed to save data to MongoDB: %v", err)
-		return err
+		return "", err
 	}
 
 	log.Println("Data successfully saved to MongoDB!")
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return id.Hex(), nil
+	}
+	return fmt.Sprint(result.InsertedID), nil
+}
+
+func (r *mongoRepository) FindChat(ctx context.Context, collection string, chatID string) (domain.Chat, error) {
+	var chat domain.Chat
+	id, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return chat, ErrNotFound
+	}
+
+	coll := r.client.Database(r.dbName).Collection(collection)
+	err = coll.FindOne(ctx, bson.M{"_id": id}).Decode(&chat)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return chat, ErrNotFound
+	}
+	if err != nil {
+		return chat, err
+	}
+
+	// BSON decodes formatted text into primitive.A and primitive.D; turn them
+	// back into the shapes encoding/json produces so the usecase sees one format.
+	for i := range chat.Messages {
+		chat.Messages[i].Text = plainValue(chat.Messages[i].Text)
+	}
+	return chat, nil
+}
+
+// SaveSearchIndex upserts the term documents of a chat, so saving the same
+// index twice, even concurrently, leaves one copy of it.
+func (r *mongoRepository) SaveSearchIndex(ctx context.Context, collection string, chatID string, index domain.SearchIndex) error {
+	coll := r.client.Database(r.dbName).Collection(searchIndexCollection(collection))
+
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "chat_id", Value: 1}, {Key: "term", Value: 1}, {Key: "chunk", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	batch := make([]mongo.WriteModel, 0, searchIndexBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := coll.BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false))
+		batch = batch[:0]
+		return err
+	}
+	for term, postings := range index {
+		for chunk := 0; chunk*postingsPerChunk < len(postings); chunk++ {
+			end := (chunk + 1) * postingsPerChunk
+			if end > len(postings) {
+				end = len(postings)
+			}
+			document := searchTermDocument{ChatID: chatID, Term: term, Chunk: chunk, Postings: postings[chunk*postingsPerChunk : end]}
+			batch = append(batch, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"chat_id": chatID, "term": term, "chunk": chunk}).
+				SetReplacement(document).
+				SetUpsert(true))
+			if len(batch) == searchIndexBatchSize {
+				if err := flush(); err != nil {
+					log.Printf("Failed to save search index to MongoDB: %v", err)
+					return err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		log.Printf("Failed to save search index to MongoDB: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) FindPostings(ctx context.Context, collection string, chatID string, terms []string) (domain.SearchIndex, error) {
+	coll := r.client.Database(r.dbName).Collection(searchIndexCollection(collection))
+
+	// A chat with words has at least one term document; the caller has to
+	// tell a chat without index from one without words.
+	count, err := coll.CountDocuments(ctx, bson.M{"chat_id": chatID}, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrNotIndexed
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{"chat_id": chatID, "term": bson.M{"$in": terms}},
+		options.Find().SetSort(bson.D{{Key: "term", Value: 1}, {Key: "chunk", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var documents []searchTermDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	index := make(domain.SearchIndex)
+	for _, document := range documents {
+		index[document.Term] = append(index[document.Term], document.Postings...)
+	}
+	return index, nil
+}
+
+// FindMessages returns the messages at the given positions of a stored chat
+// without loading the rest of it. Positions past the end are left out.
+func (r *mongoRepository) FindMessages(ctx context.Context, collection string, chatID string, positions []int) (map[int]domain.Message, error) {
+	id, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	// $arrayElemAt counts negative positions from the end.
+	wanted := make([]int, 0, len(positions))
+	for _, position := range positions {
+		if position >= 0 {
+			wanted = append(wanted, position)
+		}
+	}
+
+	coll := r.client.Database(r.dbName).Collection(collection)
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": id}}},
+		{{Key: "$project", Value: bson.M{"messages": bson.M{"$map": bson.M{
+			"input": wanted,
+			"as":    "position",
+			"in": bson.M{
+				"position": "$$position",
+				"message":  bson.M{"$arrayElemAt": bson.A{"$messages", "$$position"}},
+			},
+		}}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var documents []struct {
+		Messages []struct {
+			Position int             `bson:"position"`
+			Message  *domain.Message `bson:"message"`
+		} `bson:"messages"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	if len(documents) == 0 {
+		return nil, ErrNotFound
+	}
+
+	messages := make(map[int]domain.Message, len(wanted))
+	for _, found := range documents[0].Messages {
+		if found.Message == nil {
+			continue
+		}
+		found.Message.Text = plainValue(found.Message.Text)
+		messages[found.Position] = *found.Message
+	}
+	return messages, nil
+}
+
+// DeleteChat removes a chat together with its search index.
+func (r *mongoRepository) DeleteChat(ctx context.Context, collection string, chatID string) error {
+	id, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	db := r.client.Database(r.dbName)
+	if _, err := db.Collection(searchIndexCollection(collection)).DeleteMany(ctx, bson.M{"chat_id": chatID}); err != nil {
+		log.Printf("Failed to delete search index from MongoDB: %v", err)
+		return err
+	}
+	if _, err := db.Collection(collection).DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Printf("Failed to delete data from MongoDB: %v", err)
+		return err
+	}
 	return nil
 }
+
+func searchIndexCollection(collection string) string {
+	return collection + "_search_index"
+}
+
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.A:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = plainValue(item)
+		}
+		return items
+	case primitive.D:
+		fields := make(map[string]interface{}, len(v))
+		for _, field := range v {
+			fields[field.Key] = plainValue(field.Value)
+		}
+		return fields
+	case primitive.M:
+		fields := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			fields[key] = plainValue(item)
+		}
+		return fields
+	}
+	return value
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// Metrics checked by Anomalies besides MetricMessages.
+const (
+	MetricReplyTime = "replyTime"
+	MetricSentiment = "sentiment"
+)
+
+// Anomaly kinds.
+const (
+	AnomalySpike = "spike"
+	AnomalyDrop  = "drop"
+)
+
+// AnomalyOptions controls Anomalies.
+type AnomalyOptions struct {
+	// Location is the timezone periods are aligned to. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Granularity is day or week.
+	Granularity string
+	// WeekStart is the first day of a week when Granularity is week.
+	WeekStart time.Weekday
+	// Neighbors is how many comparable periods on each side form the baseline.
+	Neighbors int
+	// Threshold is the robust z-score from which a period is flagged.
+	Threshold float64
+	// SessionGap limits reply times to replies within the same conversation.
+	SessionGap time.Duration
+}
+
+const (
+	// madScale turns the median absolute deviation into a standard deviation
+	// estimate for normally distributed data (Iglewicz and Hoaglin).
+	madScale = 0.6745
+	// minSentimentMessages is how many scored messages a period needs before
+	// its average mood is compared.
+	minSentimentMessages = 3
+	anomalyTopWords      = 5
+	anomalySamples       = 3
+)
+
+// minAnomalyScale keeps a perfectly regular baseline (MAD of zero) from
+// turning tiny differences into huge scores.
+var minAnomalyScale = map[string]float64{
+	MetricMessages:  1,
+	MetricReplyTime: 60,
+	MetricSentiment: 0.05,
+}
+
+// anomalyPeriod collects everything measured for one day or week.
+type anomalyPeriod struct {
+	start     time.Time
+	messages  []timedMessage
+	replies   []float64
+	sentiment []float64
+}
+
+// Anomalies flags days or weeks whose message volume, median reply time or
+// average sentiment is unusually high or low. Each period is compared with
+// the median of its neighbours, scaled by their median absolute deviation;
+// for days only the same weekday is used, so a regular busy Sunday is not an
+// anomaly. Every anomaly comes with context about what was said.
+func (u *messageUsecase) Anomalies(chat domain.Chat, opts AnomalyOptions) (domain.AnomalyReport, error) {
+	if opts.Granularity != GranularityDay && opts.Granularity != GranularityWeek {
+		return domain.AnomalyReport{}, fmt.Errorf("granularity must be day or week")
+	}
+	lexicons, err := u.selectLexicons(nil)
+	if err != nil {
+		return domain.AnomalyReport{}, err
+	}
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+
+	report := domain.AnomalyReport{
+		Granularity: opts.Granularity,
+		Threshold:   opts.Threshold,
+		Anomalies:   []domain.Anomaly{},
+	}
+	messages := u.sortedMessages(chat, opts.Location)
+	if len(messages) == 0 {
+		return report, nil
+	}
+
+	var periods []*anomalyPeriod
+	index := make(map[string]int)
+	last := periodStart(messages[len(messages)-1].At, opts.Granularity, opts.WeekStart)
+	for period := periodStart(messages[0].At, opts.Granularity, opts.WeekStart); !period.After(last); period = nextPeriod(period, opts.Granularity, 1) {
+		index[period.Format(dateLayout)] = len(periods)
+		periods = append(periods, &anomalyPeriod{start: period})
+	}
+	for i, message := range messages {
+		period := periods[index[periodStart(message.At, opts.Granularity, opts.WeekStart).Format(dateLayout)]]
+		period.messages = append(period.messages, message)
+		if delay, ok := replyDelay(messages, i, opts.SessionGap); ok {
+			period.replies = append(period.replies, delay.Seconds())
+		}
+		if polarity, scored := messagePolarity(messageText(message.Message), lexicons); scored {
+			period.sentiment = append(period.sentiment, polarity)
+		}
+	}
+
+	// Every metric is a value per period; NaN marks periods without one.
+	values := map[string][]float64{
+		MetricMessages:  make([]float64, len(periods)),
+		MetricReplyTime: make([]float64, len(periods)),
+		MetricSentiment: make([]float64, len(periods)),
+	}
+	for i, period := range periods {
+		values[MetricMessages][i] = float64(len(period.messages))
+		values[MetricReplyTime][i] = math.NaN()
+		if len(period.replies) > 0 {
+			values[MetricReplyTime][i] = median(period.replies)
+		}
+		values[MetricSentiment][i] = math.NaN()
+		if len(period.sentiment) >= minSentimentMessages {
+			values[MetricSentiment][i] = mean(period.sentiment)
+		}
+	}
+
+	// Days are compared with the same weekday in the surrounding weeks.
+	step := 1
+	if opts.Granularity == GranularityDay {
+		step = 7
+	}
+	for _, metric := range []string{MetricMessages, MetricReplyTime, MetricSentiment} {
+		series := values[metric]
+		for i, value := range series {
+			if math.IsNaN(value) {
+				continue
+			}
+			var baseline []float64
+			for k := 1; k <= opts.Neighbors; k++ {
+				for _, j := range []int{i - k*step, i + k*step} {
+					if j >= 0 && j < len(series) && !math.IsNaN(series[j]) {
+						baseline = append(baseline, series[j])
+					}
+				}
+			}
+			if len(baseline) < 3 {
+				continue
+			}
+			expected := median(baseline)
+			deviations := make([]float64, len(baseline))
+			for k, neighbor := range baseline {
+				deviations[k] = math.Abs(neighbor - expected)
+			}
+			scale := math.Max(median(deviations), minAnomalyScale[metric])
+			score := madScale * (value - expected) / scale
+			if math.Abs(score) < opts.Threshold {
+				continue
+			}
+
+			kind := AnomalySpike
+			if score < 0 {
+				kind = AnomalyDrop
+			}
+			report.Anomalies = append(report.Anomalies, domain.Anomaly{
+				Period:   periods[i].start.Format(dateLayout),
+				Metric:   metric,
+				Kind:     kind,
+				Value:    roundTo(value, 4),
+				Expected: roundTo(expected, 4),
+				Score:    roundTo(score, 2),
+				Context:  anomalyContext(periods[i].messages, personOne, personTwo),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Anomalies, func(i, j int) bool {
+		if report.Anomalies[i].Period != report.Anomalies[j].Period {
+			return report.Anomalies[i].Period < report.Anomalies[j].Period
+		}
+		return math.Abs(report.Anomalies[i].Score) > math.Abs(report.Anomalies[j].Score)
+	})
+	return report, nil
+}
+
+// anomalyContext summarises a period's messages: counts per participant,
+// the most used words that are not stopwords, and the longest messages.
+func anomalyContext(messages []timedMessage, personOne, personTwo string) domain.AnomalyContext {
+	context := domain.AnomalyContext{
+		Messages: map[string]int{personOne: 0, personTwo: 0},
+		TopWords: []string{},
+		Samples:  []string{},
+	}
+	texts := make([]string, 0, len(messages))
+	for _, message := range messages {
+		context.Messages[message.From]++
+		if text := messageText(message.Message); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	words, _ := topWordsAndEmoji(messages, []string{personOne, personTwo}, anomalyTopWords)
+	for _, word := range words {
+		context.TopWords = append(context.TopWords, word.Text)
+	}
+
+	sort.SliceStable(texts, func(i, j int) bool {
+		return utf8.RuneCountInString(texts[i]) > utf8.RuneCountInString(texts[j])
+	})
+	if len(texts) > anomalySamples {
+		texts = texts[:anomalySamples]
+	}
+	context.Samples = append(context.Samples, texts...)
+	return context
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnomaliesComparesSameWeekday(t *testing.T) {
+	chat := newTestChat()
+	start := date(2024, 1, 1, 9, 0) // a Monday
+	spike := date(2024, 1, 24, 9, 0)
+	for day := 0; day < 56; day++ {
+		at := start.AddDate(0, 0, day)
+		count := 2
+		if at.Weekday() == time.Sunday {
+			count = 10
+		}
+		if at.Equal(spike) {
+			count = 30
+		}
+		for i := 0; i < count; i++ {
+			from := "Alice"
+			if i%2 == 1 {
+				from = "Bob"
+			}
+			chat.Add(at.Add(time.Duration(i)*time.Minute), from, "plan")
+		}
+	}
+
+	report, err := newTestUsecase(t).Anomalies(chat.Chat, AnomalyOptions{
+		Granularity: GranularityDay,
+		WeekStart:   time.Monday,
+		Neighbors:   4,
+		Threshold:   3.5,
+		SessionGap:  time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Anomalies) != 1 {
+		t.Fatalf("anomalies = %+v; want only the spike", report.Anomalies)
+	}
+	got := report.Anomalies[0]
+	if got.Period != "2024-01-24" || got.Metric != MetricMessages || got.Kind != AnomalySpike || got.Expected != 2 {
+		t.Fatalf("anomaly = %+v; want a message spike on 2024-01-24 against 2 expected", got)
+	}
+	if got.Context.Messages["Alice"] != 15 || got.Context.Messages["Bob"] != 15 {
+		t.Errorf("context messages = %v; want 15 each", got.Context.Messages)
+	}
+}
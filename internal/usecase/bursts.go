@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// BurstOptions controls Bursts.
+type BurstOptions struct {
+	// Location is the timezone monologue times are reported in. Nil keeps the export's wall clock.
+	Location *time.Location
+	// SessionGap ends a turn even when the same person writes again after it.
+	SessionGap time.Duration
+	// Top is how many monologues to return.
+	Top int
+}
+
+// burstBuckets are the upper bounds of the burst length buckets; longer
+// bursts fall into the open-ended last bucket.
+var burstBuckets = []int{1, 2, 3, 4, 9}
+
+// Bursts measures double-texting: how many messages each participant sends
+// per turn, how often a turn holds more than one message, how turn lengths
+// are distributed and which turns were the longest monologues.
+func (u *messageUsecase) Bursts(chat domain.Chat, opts BurstOptions) (domain.BurstReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	people := make(map[string]domain.BurstStats, len(participants))
+	words := make(map[string]int, len(participants))
+	for _, person := range participants {
+		distribution := make(map[string]int, len(burstBuckets)+1)
+		for _, label := range burstBucketLabels() {
+			distribution[label] = 0
+		}
+		people[person] = domain.BurstStats{Distribution: distribution}
+	}
+
+	var monologues []domain.Monologue
+	for _, t := range splitTurns(u.sortedMessages(chat, opts.Location), opts.SessionGap) {
+		turnWords := 0
+		for _, message := range t.Messages {
+			turnWords += len(wordTokens(messageText(message.Message)))
+		}
+		words[t.From] += turnWords
+
+		stats := people[t.From]
+		stats.Messages += len(t.Messages)
+		stats.Turns++
+		if len(t.Messages) > 1 {
+			stats.DoubleTexts++
+		}
+		if len(t.Messages) > stats.LongestBurst {
+			stats.LongestBurst = len(t.Messages)
+		}
+		stats.Distribution[burstBucket(len(t.Messages))]++
+		people[t.From] = stats
+
+		if len(t.Messages) < 2 {
+			continue
+		}
+		monologues = append(monologues, domain.Monologue{
+			From:            t.From,
+			Start:           t.Start().Format(dateTimeLayout),
+			End:             t.End().Format(dateTimeLayout),
+			Messages:        len(t.Messages),
+			Words:           turnWords,
+			DurationSeconds: int(t.End().Sub(t.Start()).Seconds()),
+		})
+	}
+
+	for person, stats := range people {
+		if stats.Turns > 0 {
+			stats.MessagesPerTurn = roundTo(float64(stats.Messages)/float64(stats.Turns), 2)
+			stats.WordsPerTurn = roundTo(float64(words[person])/float64(stats.Turns), 2)
+			stats.DoubleTextRate = roundTo(float64(stats.DoubleTexts)/float64(stats.Turns), 4)
+		}
+		people[person] = stats
+	}
+
+	sort.SliceStable(monologues, func(i, j int) bool {
+		if monologues[i].Messages != monologues[j].Messages {
+			return monologues[i].Messages > monologues[j].Messages
+		}
+		return monologues[i].Words > monologues[j].Words
+	})
+	if len(monologues) > opts.Top {
+		monologues = monologues[:opts.Top]
+	}
+	if monologues == nil {
+		monologues = []domain.Monologue{}
+	}
+	return domain.BurstReport{People: people, Monologues: monologues}, nil
+}
+
+// burstBucket labels a burst length: "1" to "4", "5-9" and "10+".
+func burstBucket(length int) string {
+	lower := 1
+	for _, upper := range burstBuckets {
+		if length <= upper {
+			if lower == upper {
+				return strconv.Itoa(upper)
+			}
+			return strconv.Itoa(lower) + "-" + strconv.Itoa(upper)
+		}
+		lower = upper + 1
+	}
+	return strconv.Itoa(lower) + "+"
+}
+
+func burstBucketLabels() []string {
+	labels := make([]string, 0, len(burstBuckets)+1)
+	lower := 1
+	for _, upper := range burstBuckets {
+		labels = append(labels, burstBucket(upper))
+		lower = upper + 1
+	}
+	return append(labels, burstBucket(lower))
+}
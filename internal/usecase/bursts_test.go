@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBursts(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 1, 9, 1), "Alice", "are you there").
+		Add(date(2024, 1, 1, 9, 2), "Alice", "hello?").
+		Add(date(2024, 1, 1, 9, 5), "Bob", "yes").
+		Add(date(2024, 1, 1, 9, 6), "Alice", "ok").
+		// Three days of silence separate Alice's next message from her last.
+		Add(date(2024, 1, 4, 9, 0), "Alice", "morning").
+		Add(date(2024, 1, 4, 9, 1), "Bob", "morning").
+		Add(date(2024, 1, 4, 9, 2), "Bob", "how are you")
+
+	report, err := newTestUsecase(t).Bursts(chat.Chat, BurstOptions{SessionGap: time.Hour, Top: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		person       string
+		messages     int
+		turns        int
+		doubleTexts  int
+		longestBurst int
+		distribution map[string]int
+	}{
+		{"Alice", 5, 3, 1, 3, map[string]int{"1": 2, "3": 1}},
+		{"Bob", 3, 2, 1, 2, map[string]int{"1": 1, "2": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.person, func(t *testing.T) {
+			stats := report.People[tt.person]
+			if stats.Messages != tt.messages || stats.Turns != tt.turns || stats.DoubleTexts != tt.doubleTexts || stats.LongestBurst != tt.longestBurst {
+				t.Errorf("stats = %+v; want %d messages in %d turns, %d double texts, longest %d", stats, tt.messages, tt.turns, tt.doubleTexts, tt.longestBurst)
+			}
+			for bucket, count := range tt.distribution {
+				if stats.Distribution[bucket] != count {
+					t.Errorf("distribution = %v; want %v", stats.Distribution, tt.distribution)
+					break
+				}
+			}
+		})
+	}
+
+	if len(report.Monologues) != 2 {
+		t.Fatalf("monologues = %+v; want two", report.Monologues)
+	}
+	if first := report.Monologues[0]; first.From != "Alice" || first.Messages != 3 || first.Words != 5 || first.DurationSeconds != 120 {
+		t.Errorf("longest monologue = %+v; want Alice's 3 messages over 2 minutes", first)
+	}
+}
+
+func TestSplitTurnsBreaksAtGap(t *testing.T) {
+	messages := []timedMessage{
+		{At: date(2024, 1, 1, 9, 0)},
+		{At: date(2024, 1, 1, 9, 30)},
+		{At: date(2024, 1, 3, 9, 0)},
+	}
+	for i := range messages {
+		messages[i].From = "Alice"
+	}
+	turns := splitTurns(messages, time.Hour)
+	if len(turns) != 2 || len(turns[0].Messages) != 2 || len(turns[1].Messages) != 1 {
+		t.Errorf("turns = %+v; want two and one message", turns)
+	}
+}
+
+func TestBurstBucket(t *testing.T) {
+	tests := []struct {
+		length int
+		want   string
+	}{
+		{1, "1"}, {4, "4"}, {5, "5-9"}, {9, "5-9"}, {10, "10+"}, {42, "10+"},
+	}
+	for _, tt := range tests {
+		if got := burstBucket(tt.length); got != tt.want {
+			t.Errorf("burstBucket(%d) = %q; want %q", tt.length, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"math"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// ChronotypeOptions controls Chronotypes.
+type ChronotypeOptions struct {
+	// Location is the timezone used for times of day. Nil keeps the export's wall clock.
+	Location *time.Location
+	// DayStartHour is the hour a person's day is taken to begin, so messages
+	// sent after midnight count as the end of the previous day.
+	DayStartHour int
+}
+
+const (
+	// activeWindowCoverage is the share of messages the active window must hold.
+	activeWindowCoverage = 0.8
+	// earlyBirdMidpoint and nightOwlMidpoint bound the "intermediate"
+	// chronotype by the midpoint of the inferred sleep window, in minutes.
+	earlyBirdMidpoint = 3 * 60
+	nightOwlMidpoint  = 5 * 60
+)
+
+// Chronotypes estimates each participant's daily rhythm from message times:
+// the hours they are active, when their day typically starts and ends on
+// weekdays and weekends, the sleep window in between, and how well the two
+// rhythms overlap.
+func (u *messageUsecase) Chronotypes(chat domain.Chat, opts ChronotypeOptions) (domain.ChronotypeReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+	dayStart := time.Duration(opts.DayStartHour) * time.Hour
+
+	type dayBounds struct {
+		first, last time.Time
+		weekend     bool
+	}
+	hourly := map[string][]int{personOne: make([]int, 24), personTwo: make([]int, 24)}
+	days := map[string]map[int]*dayBounds{personOne: {}, personTwo: {}}
+	for _, message := range u.sortedMessages(chat, opts.Location) {
+		hourly[message.From][message.At.Hour()]++
+
+		shifted := message.At.Add(-dayStart)
+		day := dayNumber(shifted)
+		bounds := days[message.From][day]
+		if bounds == nil {
+			weekday := shifted.Weekday()
+			days[message.From][day] = &dayBounds{
+				first:   message.At,
+				last:    message.At,
+				weekend: weekday == time.Saturday || weekday == time.Sunday,
+			}
+			continue
+		}
+		bounds.last = message.At
+	}
+
+	report := domain.ChronotypeReport{People: make(map[string]domain.Chronotype, len(participants))}
+	shares := make(map[string][]float64, len(participants))
+	windows := make(map[string][]bool, len(participants))
+	for _, person := range participants {
+		total := 0
+		for _, count := range hourly[person] {
+			total += count
+		}
+		shares[person] = make([]float64, 24)
+		profile := domain.Chronotype{Messages: total, Hourly: make([]float64, 24)}
+		if total == 0 {
+			report.People[person] = profile
+			windows[person] = make([]bool, 24)
+			continue
+		}
+		for hour, count := range hourly[person] {
+			shares[person][hour] = float64(count) / float64(total)
+			profile.Hourly[hour] = roundTo(shares[person][hour], 4)
+		}
+
+		start, length := activeWindow(hourly[person], total)
+		windows[person] = make([]bool, 24)
+		for h := 0; h < length; h++ {
+			windows[person][(start+h)%24] = true
+		}
+		profile.ActiveWindow = domain.ClockWindow{
+			Start: formatMinuteOfDay(start * 60),
+			End:   formatMinuteOfDay((start + length) * 60),
+			Hours: float64(length),
+		}
+
+		var firsts, lasts [2][]int
+		for _, bounds := range days[person] {
+			group := 0
+			if bounds.weekend {
+				group = 1
+			}
+			firsts[group] = append(firsts[group], minuteOfDay(bounds.first))
+			lasts[group] = append(lasts[group], minuteOfDay(bounds.last))
+		}
+		allFirsts := append(append([]int(nil), firsts[0]...), firsts[1]...)
+		allLasts := append(append([]int(nil), lasts[0]...), lasts[1]...)
+		first, last := typicalMinuteOfDay(allFirsts), typicalMinuteOfDay(allLasts)
+		profile.Rhythm = domain.DailyRhythm{FirstMessage: formatMinuteOfDay(first), LastMessage: formatMinuteOfDay(last)}
+
+		sleepLength := circularMinutes(first - last)
+		midpoint := (last + sleepLength/2) % minutesPerDay
+		profile.Sleep = domain.ClockWindow{
+			Start: formatMinuteOfDay(last),
+			End:   formatMinuteOfDay(first),
+			Hours: roundTo(float64(sleepLength)/60, 2),
+		}
+		profile.Type = "intermediate"
+		if midpoint < earlyBirdMidpoint || midpoint >= 18*60 {
+			profile.Type = "early bird"
+		} else if midpoint > nightOwlMidpoint {
+			profile.Type = "night owl"
+		}
+
+		var midpoints [2]int
+		for group := range firsts {
+			if len(firsts[group]) == 0 {
+				continue
+			}
+			groupFirst, groupLast := typicalMinuteOfDay(firsts[group]), typicalMinuteOfDay(lasts[group])
+			rhythm := domain.DailyRhythm{FirstMessage: formatMinuteOfDay(groupFirst), LastMessage: formatMinuteOfDay(groupLast)}
+			if group == 0 {
+				profile.Weekday = rhythm
+			} else {
+				profile.Weekend = rhythm
+			}
+			midpoints[group] = groupLast + circularMinutes(groupFirst-groupLast)/2
+		}
+		if len(firsts[0]) > 0 && len(firsts[1]) > 0 {
+			profile.WeekendShiftMinutes = signedCircularMinutes(midpoints[1] - midpoints[0])
+		}
+		report.People[person] = profile
+	}
+
+	intersection := 0.0
+	for hour := 0; hour < 24; hour++ {
+		intersection += math.Min(shares[personOne][hour], shares[personTwo][hour])
+		if windows[personOne][hour] && windows[personTwo][hour] {
+			report.OverlapHours++
+		}
+	}
+	report.Compatibility = roundTo(intersection, 4)
+	return report, nil
+}
+
+// activeWindow finds the shortest run of consecutive hours, wrapping past
+// midnight, holding at least activeWindowCoverage of the messages. Among
+// equally short runs the busiest one wins.
+func activeWindow(hourly []int, total int) (start, length int) {
+	needed := activeWindowCoverage * float64(total)
+	for length = 1; length <= 24; length++ {
+		best, bestCount := -1, 0
+		for s := 0; s < 24; s++ {
+			count := 0
+			for h := 0; h < length; h++ {
+				count += hourly[(s+h)%24]
+			}
+			if float64(count) >= needed && count > bestCount {
+				best, bestCount = s, count
+			}
+		}
+		if best >= 0 {
+			return best, length
+		}
+	}
+	return 0, 24
+}
+
+// signedCircularMinutes wraps a difference of times of day into [-12h, 12h).
+func signedCircularMinutes(minutes int) int {
+	return circularMinutes(minutes+minutesPerDay/2) - minutesPerDay/2
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import "testing"
+
+func TestActiveWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[int]int
+		start  int
+		length int
+	}{
+		{"single busy hour", map[int]int{9: 8, 10: 1, 20: 1}, 9, 1},
+		{"evening", map[int]int{21: 1, 22: 4, 23: 4, 12: 1}, 22, 2},
+		{"across midnight", map[int]int{23: 4, 0: 4, 12: 2}, 23, 2},
+		{"busiest of equally short runs", map[int]int{9: 1, 10: 8, 11: 2}, 10, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hourly := make([]int, 24)
+			total := 0
+			for hour, count := range tt.counts {
+				hourly[hour] = count
+				total += count
+			}
+			start, length := activeWindow(hourly, total)
+			if start != tt.start || length != tt.length {
+				t.Errorf("activeWindow = %d, %d; want %d, %d", start, length, tt.start, tt.length)
+			}
+		})
+	}
+}
+
+func TestChronotypeClassification(t *testing.T) {
+	tests := []struct {
+		name        string
+		firstHour   int
+		lastHour    int
+		wantType    string
+		wantSleep   string
+		sleepLength float64
+	}{
+		{"early bird", 6, 21, "early bird", "21:00-06:00", 9},
+		{"intermediate", 8, 23, "intermediate", "23:00-08:00", 9},
+		{"night owl", 11, 2, "night owl", "02:00-11:00", 9},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat := newTestChat()
+			// 2024-01-01 is a Monday; five weekdays of the same rhythm.
+			for day := 1; day <= 5; day++ {
+				last := date(2024, 1, day, tt.lastHour, 0)
+				if tt.lastHour < tt.firstHour {
+					last = last.AddDate(0, 0, 1)
+				}
+				chat.Add(date(2024, 1, day, tt.firstHour, 0), "Alice", "up").
+					Add(date(2024, 1, day, 15, 0), "Bob", "hi").
+					Add(last, "Alice", "bed")
+			}
+
+			report, err := u.Chronotypes(chat.Chat, ChronotypeOptions{DayStartHour: 4})
+			if err != nil {
+				t.Fatal(err)
+			}
+			alice := report.People["Alice"]
+			if alice.Type != tt.wantType {
+				t.Errorf("type = %q; want %q", alice.Type, tt.wantType)
+			}
+			if got := alice.Sleep.Start + "-" + alice.Sleep.End; got != tt.wantSleep || alice.Sleep.Hours != tt.sleepLength {
+				t.Errorf("sleep = %s (%vh); want %s (%vh)", got, alice.Sleep.Hours, tt.wantSleep, tt.sleepLength)
+			}
+		})
+	}
+}
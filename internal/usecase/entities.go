@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"unicode/utf8"
+)
+
+// formattingEntities are the entity types that only change how text looks.
+var formattingEntities = map[string]bool{
+	"bold": true, "italic": true, "underline": true, "strikethrough": true,
+	"code": true, "pre": true, "spoiler": true, "blockquote": true,
+}
+
+// Entities counts each participant's text entities by type, how many of
+// their messages use formatting, whom they mention and which hashtags they use.
+func (u *messageUsecase) Entities(chat domain.Chat, top int) (domain.EntityReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	report := domain.EntityReport{
+		People:      make(map[string]domain.EntityUsage, len(participants)),
+		Mentions:    make(map[string][]domain.EntityCount, len(participants)),
+		TopHashtags: []domain.EntityCount{},
+	}
+	messages := make(map[string]int, len(participants))
+	for _, person := range participants {
+		report.People[person] = domain.EntityUsage{Counts: make(map[string]int)}
+	}
+	mentions := map[string]map[string]*domain.EntityCount{personOne: {}, personTwo: {}}
+	hashtags := make(map[string]*domain.EntityCount)
+
+	for _, message := range chat.Messages {
+		if isServiceMessage(message) || (message.From != personOne && message.From != personTwo) {
+			continue
+		}
+		messages[message.From]++
+		usage := report.People[message.From]
+		formatted := false
+		for _, entity := range messageEntities(message) {
+			// Exports wrap unformatted stretches of text in "plain" entities.
+			if entity.Type == "" || entity.Type == "plain" {
+				continue
+			}
+			usage.Counts[entity.Type]++
+			if formattingEntities[entity.Type] {
+				formatted = true
+			}
+
+			text := strings.ToLower(strings.TrimSpace(entity.Text))
+			switch entity.Type {
+			case "mention", "mention_name":
+				countEntity(mentions[message.From], text, message.From, participants)
+			case "hashtag":
+				countEntity(hashtags, text, message.From, participants)
+			}
+		}
+		if formatted {
+			usage.FormattedMessages++
+		}
+		report.People[message.From] = usage
+	}
+
+	for _, person := range participants {
+		usage := report.People[person]
+		if messages[person] > 0 {
+			usage.FormattingShare = roundTo(float64(usage.FormattedMessages)/float64(messages[person]), 4)
+		}
+		report.People[person] = usage
+		report.Mentions[person] = topEntityCounts(mentions[person], top)
+	}
+	report.TopHashtags = topEntityCounts(hashtags, top)
+	return report, nil
+}
+
+func countEntity(counts map[string]*domain.EntityCount, text, from string, participants []string) {
+	if text == "" {
+		return
+	}
+	if counts[text] == nil {
+		counts[text] = &domain.EntityCount{Text: text, Counts: make(map[string]int, len(participants))}
+		for _, person := range participants {
+			counts[text].Counts[person] = 0
+		}
+	}
+	counts[text].Total++
+	counts[text].Counts[from]++
+}
+
+// topWordsAndEmoji ranks the words and emoji of messages by use. Words that are
+// stopwords, shorter than three letters or numbers are left out.
+func topWordsAndEmoji(messages []timedMessage, participants []string, top int) (words, emoji []domain.EntityCount) {
+	wordCounts := make(map[string]*domain.EntityCount)
+	emojiCounts := make(map[string]*domain.EntityCount)
+	for _, message := range messages {
+		for _, token := range wordsAndEmoji(messageText(message.Message)) {
+			if r := []rune(token); len(r) == 1 && isEmojiRune(r[0]) {
+				countEntity(emojiCounts, token, message.From, participants)
+				continue
+			}
+			if !englishStopwords[token] && utf8.RuneCountInString(token) > 2 && !isNumeric(token) {
+				countEntity(wordCounts, token, message.From, participants)
+			}
+		}
+	}
+	return topEntityCounts(wordCounts, top), topEntityCounts(emojiCounts, top)
+}
+
+func topEntityCounts(counts map[string]*domain.EntityCount, top int) []domain.EntityCount {
+	sorted := make([]domain.EntityCount, 0, len(counts))
+	for _, count := range counts {
+		sorted = append(sorted, *count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Total != sorted[j].Total {
+			return sorted[i].Total > sorted[j].Total
+		}
+		return sorted[i].Text < sorted[j].Text
+	})
+	if len(sorted) > top {
+		sorted = sorted[:top]
+	}
+	return sorted
+}
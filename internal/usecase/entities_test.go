@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestMessageEntities(t *testing.T) {
+	tests := []struct {
+		name    string
+		message domain.Message
+		want    []domain.TextEntity
+	}{
+		{
+			name: "text entities",
+			message: domain.Message{
+				Text:         "hi @bob",
+				TextEntities: []domain.TextEntity{{Type: "plain", Text: "hi "}, {Type: "mention", Text: "@bob"}},
+			},
+			want: []domain.TextEntity{{Type: "plain", Text: "hi "}, {Type: "mention", Text: "@bob"}},
+		},
+		{
+			name: "formatted text",
+			message: domain.Message{Text: []interface{}{
+				"see ",
+				map[string]interface{}{"type": "text_link", "text": "this", "href": "https://example.com"},
+				map[string]interface{}{"type": "hashtag", "text": "#Go"},
+			}},
+			want: []domain.TextEntity{{Type: "text_link", Text: "this", Href: "https://example.com"}, {Type: "hashtag", Text: "#Go"}},
+		},
+		{name: "plain string", message: domain.Message{Text: "no entities"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageEntities(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messageEntities = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopEntityCounts(t *testing.T) {
+	participants := []string{"Alice", "Bob"}
+	counts := make(map[string]*domain.EntityCount)
+	for _, use := range []struct{ text, from string }{
+		{"#go", "Alice"}, {"#go", "Bob"}, {"#rust", "Bob"}, {"#rust", "Bob"},
+		{"#zig", "Alice"}, {"#c", "Alice"}, {"", "Alice"},
+	} {
+		countEntity(counts, use.text, use.from, participants)
+	}
+
+	got := topEntityCounts(counts, 3)
+	// Ties are broken alphabetically, so #c makes the cut ahead of #zig.
+	want := []domain.EntityCount{
+		{Text: "#go", Total: 2, Counts: map[string]int{"Alice": 1, "Bob": 1}},
+		{Text: "#rust", Total: 2, Counts: map[string]int{"Alice": 0, "Bob": 2}},
+		{Text: "#c", Total: 1, Counts: map[string]int{"Alice": 1, "Bob": 0}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("topEntityCounts = %+v; want %+v", got, want)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "").
+		Add(date(2024, 1, 1, 9, 1), "Bob", "").
+		Add(date(2024, 1, 1, 9, 2), "Alice", "plain")
+	chat.Messages[0].TextEntities = []domain.TextEntity{
+		{Type: "bold", Text: "hey"}, {Type: "plain", Text: " "}, {Type: "mention", Text: "@Bob"}, {Type: "hashtag", Text: "#Weekend"},
+	}
+	chat.Messages[1].TextEntities = []domain.TextEntity{{Type: "hashtag", Text: "#weekend"}, {Type: "mention", Text: "@alice"}}
+
+	report, err := newTestUsecase(t).Entities(chat.Chat, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := report.People["Alice"]
+	if !reflect.DeepEqual(alice.Counts, map[string]int{"bold": 1, "mention": 1, "hashtag": 1}) || alice.FormattedMessages != 1 || alice.FormattingShare != 0.5 {
+		t.Errorf("Alice = %+v; want one bold, mention and hashtag in half her messages", alice)
+	}
+	if mentions := report.Mentions["Alice"]; len(mentions) != 1 || mentions[0].Text != "@bob" {
+		t.Errorf("Alice mentions = %+v; want @bob", mentions)
+	}
+	if len(report.TopHashtags) != 1 || report.TopHashtags[0].Total != 2 || report.TopHashtags[0].Text != "#weekend" {
+		t.Errorf("hashtags = %+v; want #weekend twice", report.TopHashtags)
+	}
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// ForecastOptions controls Forecast.
+type ForecastOptions struct {
+	// Location is the timezone days are counted in. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Weeks is how many weeks to project.
+	Weeks int
+	// HistoryDays is how many of the most recent days the model is fitted to.
+	HistoryDays int
+	// Confidence is the coverage of the prediction intervals: 0.8, 0.9 or 0.95.
+	Confidence float64
+	// Target is the message count whose date to project. Zero picks the next round number.
+	Target int
+}
+
+// confidenceZ maps supported interval coverages to normal quantiles.
+var confidenceZ = map[float64]float64{0.8: 1.2816, 0.9: 1.6449, 0.95: 1.96}
+
+// minForecastDays is how many days the history must span; fewer leave
+// too few residuals to estimate the spread of the prediction intervals.
+const minForecastDays = 14
+
+// ErrNotEnoughHistory is returned by Forecast when the messages span fewer
+// than minForecastDays days.
+var ErrNotEnoughHistory = errors.New("not enough history")
+
+// milestoneHorizonDays bounds how far ahead the milestone date is searched.
+const milestoneHorizonDays = 5 * 365
+
+// Forecast fits daily message counts with a least-squares linear trend plus
+// an additive offset per weekday, then projects daily and weekly volume for
+// the next Weeks weeks with prediction intervals and the day the chat is
+// expected to reach Target messages.
+func (u *messageUsecase) Forecast(chat domain.Chat, opts ForecastOptions) (domain.Forecast, error) {
+	z, ok := confidenceZ[opts.Confidence]
+	if !ok {
+		return domain.Forecast{}, fmt.Errorf("confidence must be 0.8, 0.9 or 0.95")
+	}
+	messages := u.sortedMessages(chat, opts.Location)
+	if len(messages) == 0 {
+		return domain.Forecast{}, fmt.Errorf("no dated messages to forecast from")
+	}
+
+	firstDay := dayNumber(messages[0].At)
+	lastDay := dayNumber(messages[len(messages)-1].At)
+	counts := make([]float64, lastDay-firstDay+1)
+	for _, message := range messages {
+		counts[dayNumber(message.At)-firstDay]++
+	}
+	if len(counts) > opts.HistoryDays {
+		counts = counts[len(counts)-opts.HistoryDays:]
+	}
+	if len(counts) < minForecastDays {
+		return domain.Forecast{}, fmt.Errorf("%w: need at least %d days of messages to forecast, got %d", ErrNotEnoughHistory, minForecastDays, len(counts))
+	}
+	historyStart := lastDay - len(counts) + 1
+	n := float64(len(counts))
+
+	// Least-squares trend over day offsets 0..n-1.
+	tMean := (n - 1) / 2
+	yMean := mean(counts)
+	sxx, sxy := 0.0, 0.0
+	for t, y := range counts {
+		sxx += (float64(t) - tMean) * (float64(t) - tMean)
+		sxy += (float64(t) - tMean) * (y - yMean)
+	}
+	slope := 0.0
+	if sxx > 0 {
+		slope = sxy / sxx
+	}
+	intercept := yMean - slope*tMean
+
+	// Weekday offsets are the mean residual of each weekday after the trend.
+	var offsets, weekdayDays [7]float64
+	for t, y := range counts {
+		weekday := dayWeekday(historyStart + t)
+		offsets[weekday] += y - (intercept + slope*float64(t))
+		weekdayDays[weekday]++
+	}
+	for weekday := range offsets {
+		if weekdayDays[weekday] > 0 {
+			offsets[weekday] /= weekdayDays[weekday]
+		}
+	}
+
+	residualSum := 0.0
+	for t, y := range counts {
+		fitted := intercept + slope*float64(t) + offsets[dayWeekday(historyStart+t)]
+		residualSum += (y - fitted) * (y - fitted)
+	}
+	// Two trend parameters and up to seven weekday offsets were estimated.
+	degrees := n - 2 - 6
+	if degrees < 1 {
+		degrees = 1
+	}
+	sigma := math.Sqrt(residualSum / degrees)
+
+	forecast := domain.Forecast{
+		HistoryDays: len(counts),
+		Confidence:  opts.Confidence,
+		TrendPerDay: roundTo(slope, 4),
+		Weekday:     make(map[string]float64, 7),
+		Daily:       []domain.ForecastPoint{},
+		Weekly:      []domain.ForecastPoint{},
+	}
+	for weekday, offset := range offsets {
+		forecast.Weekday[time.Weekday(weekday).String()] = roundTo(offset, 2)
+	}
+
+	// predict returns the expected count for day offset t.
+	predict := func(t int) float64 {
+		return math.Max(0, intercept+slope*float64(t)+offsets[dayWeekday(historyStart+t)])
+	}
+	// variance is the prediction variance of the sum of days, whose offsets
+	// from the history's mean day add up to deviation. Each day brings its
+	// own noise, but the error of the fitted trend is shared by all of them:
+	// it grows with the square of the number of days, not linearly.
+	variance := func(days int, deviation float64) float64 {
+		k := float64(days)
+		v := sigma * sigma * (k + k*k/n)
+		if sxx > 0 {
+			v += sigma * sigma * deviation * deviation / sxx
+		}
+		return v
+	}
+
+	var week domain.ForecastPoint
+	weekDeviation := 0.0
+	for d := 1; d <= opts.Weeks*7; d++ {
+		t := len(counts) - 1 + d
+		expected := predict(t)
+		spread := z * math.Sqrt(variance(1, float64(t)-tMean))
+		date := dayToDate(lastDay + d)
+		forecast.Daily = append(forecast.Daily, domain.ForecastPoint{
+			Date:     date,
+			Expected: roundTo(expected, 2),
+			Lower:    roundTo(math.Max(0, expected-spread), 2),
+			Upper:    roundTo(expected+spread, 2),
+		})
+
+		if (d-1)%7 == 0 {
+			week = domain.ForecastPoint{Date: date}
+			weekDeviation = 0
+		}
+		week.Expected += expected
+		weekDeviation += float64(t) - tMean
+		if d%7 == 0 {
+			spread := z * math.Sqrt(variance(7, weekDeviation))
+			week.Lower = roundTo(math.Max(0, week.Expected-spread), 2)
+			week.Upper = roundTo(week.Expected+spread, 2)
+			week.Expected = roundTo(week.Expected, 2)
+			forecast.Weekly = append(forecast.Weekly, week)
+		}
+	}
+
+	current := len(messages)
+	target := opts.Target
+	if target == 0 {
+		target = nextRoundNumber(current)
+	}
+	forecast.Milestone = domain.ForecastMilestone{Target: target, Current: current}
+	total := float64(current)
+	for d := 1; d <= milestoneHorizonDays && total < float64(target); d++ {
+		total += predict(len(counts) - 1 + d)
+		if total >= float64(target) {
+			forecast.Milestone.Date = dayToDate(lastDay + d)
+			forecast.Milestone.DaysAway = d
+		}
+	}
+	if current >= target {
+		forecast.Milestone.Date = dayToDate(lastDay)
+	}
+	return forecast, nil
+}
+
+// dayWeekday returns the weekday of a day number from dayNumber.
+func dayWeekday(day int) int {
+	// Day 0 is 1970-01-01, a Thursday.
+	return (day%7 + 7 + int(time.Thursday)) % 7
+}
+
+// nextRoundNumber returns the smallest of 1, 2.5 and 5 times a power of ten
+// that is greater than count, e.g. 10000 after 7431.
+func nextRoundNumber(count int) int {
+	for power := 10; ; power *= 10 {
+		for _, multiple := range []int{10, 25, 50} {
+			if candidate := power * multiple / 10; candidate > count {
+				return candidate
+			}
+		}
+	}
+}
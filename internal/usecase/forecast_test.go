@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// dailyChat adds counts[i] messages on the i-th day after start.
+func dailyChat(start time.Time, counts []int) *testChat {
+	chat := newTestChat()
+	for day, count := range counts {
+		for i := 0; i < count; i++ {
+			from := "Alice"
+			if i%2 == 1 {
+				from = "Bob"
+			}
+			chat.Add(start.AddDate(0, 0, day).Add(time.Duration(i)*time.Minute), from, "hi")
+		}
+	}
+	return chat
+}
+
+func TestForecastWeekdayPattern(t *testing.T) {
+	// Four weeks and a day from Monday 2024-01-01, so the Mondays are centred
+	// on the history: 10 messages on Mondays, 2 otherwise.
+	counts := make([]int, 29)
+	for day := range counts {
+		counts[day] = 2
+		if day%7 == 0 {
+			counts[day] = 10
+		}
+	}
+	chat := dailyChat(date(2024, 1, 1, 9, 0), counts)
+
+	forecast, err := newTestUsecase(t).Forecast(chat.Chat, ForecastOptions{Weeks: 2, HistoryDays: 180, Confidence: 0.95, Target: 141})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forecast.HistoryDays != 29 || forecast.TrendPerDay != 0 {
+		t.Errorf("history %d, trend %v; want 29 days and a flat trend", forecast.HistoryDays, forecast.TrendPerDay)
+	}
+	if len(forecast.Daily) != 14 || len(forecast.Weekly) != 2 {
+		t.Fatalf("daily %d, weekly %d; want 14 and 2", len(forecast.Daily), len(forecast.Weekly))
+	}
+	tuesday, monday := forecast.Daily[0], forecast.Daily[6]
+	if tuesday.Date != "2024-01-30" || tuesday.Expected != 2 || monday.Expected != 10 {
+		t.Errorf("tuesday %+v, monday %+v; want 2 from 2024-01-30 and 10 on Monday", tuesday, monday)
+	}
+	if week := forecast.Weekly[0]; week.Date != "2024-01-30" || week.Expected != 22 {
+		t.Errorf("week = %+v; want 22 messages from 2024-01-30", week)
+	}
+	// 98 messages so far and 22 a week: 141 is passed on the second Monday.
+	if milestone := forecast.Milestone; milestone.Current != 98 || milestone.Date != "2024-02-12" || milestone.DaysAway != 14 {
+		t.Errorf("milestone = %+v; want 141 on 2024-02-12, 14 days away", milestone)
+	}
+}
+
+func TestForecastWeeklyIntervalIncludesTrendError(t *testing.T) {
+	counts := make([]int, 42)
+	for day := range counts {
+		counts[day] = 5 + day/3 + []int{0, 3, 1, 4, 2}[day%5]
+	}
+	chat := dailyChat(date(2024, 1, 1, 9, 0), counts)
+
+	forecast, err := newTestUsecase(t).Forecast(chat.Chat, ForecastOptions{Weeks: 3, HistoryDays: 180, Confidence: 0.9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for w, week := range forecast.Weekly {
+		independent := 0.0
+		for _, day := range forecast.Daily[w*7 : w*7+7] {
+			independent += (day.Upper - day.Expected) * (day.Upper - day.Expected)
+		}
+		spread := week.Upper - week.Expected
+		if spread <= 0 || spread*spread <= independent+0.1 {
+			t.Errorf("week %d spread %.2f; want wider than independent days (%.2f)", w, spread, math.Sqrt(independent))
+		}
+	}
+}
+
+func TestForecastErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		days int
+		opts ForecastOptions
+	}{
+		{"one day", 1, ForecastOptions{Weeks: 1, HistoryDays: 180, Confidence: 0.95}},
+		{"history below minimum", 30, ForecastOptions{Weeks: 1, HistoryDays: 7, Confidence: 0.95}},
+		{"unsupported confidence", 30, ForecastOptions{Weeks: 1, HistoryDays: 180, Confidence: 0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := make([]int, tt.days)
+			for day := range counts {
+				counts[day] = 3
+			}
+			chat := dailyChat(date(2024, 1, 1, 9, 0), counts)
+			if _, err := newTestUsecase(t).Forecast(chat.Chat, tt.opts); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestForecastShortHistory(t *testing.T) {
+	chat := dailyChat(date(2024, 1, 1, 9, 0), []int{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3})
+	if _, err := newTestUsecase(t).Forecast(chat.Chat, ForecastOptions{Weeks: 1, HistoryDays: 180, Confidence: 0.95}); !errors.Is(err, ErrNotEnoughHistory) {
+		t.Errorf("err = %v; want ErrNotEnoughHistory for 13 days", err)
+	}
+}
+
+func TestNextRoundNumber(t *testing.T) {
+	tests := []struct{ count, want int }{
+		{0, 10}, {7, 10}, {10, 25}, {24, 25}, {49, 50}, {7431, 10000}, {10000, 25000},
+	}
+	for _, tt := range tests {
+		if got := nextRoundNumber(tt.count); got != tt.want {
+			t.Errorf("nextRoundNumber(%d) = %d; want %d", tt.count, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// Heatmap normalization modes.
+const (
+	NormalizeNone  = "none"  // raw message counts
+	NormalizeTotal = "total" // share of the participant's messages, summing to 1
+	NormalizeMax   = "max"   // relative to the participant's busiest cell
+)
+
+// HeatmapOptions controls how the weekday × hour grid is built.
+type HeatmapOptions struct {
+	// Location is the timezone hours are read in. Nil keeps the export's wall clock.
+	Location *time.Location
+	// WeekStart is the weekday placed in the first row.
+	WeekStart time.Weekday
+	// Normalize is none, total or max.
+	Normalize string
+}
+
+func (u *messageUsecase) ActivityHeatmap(chat domain.Chat, opts HeatmapOptions) (domain.Heatmap, error) {
+	switch opts.Normalize {
+	case NormalizeNone, NormalizeTotal, NormalizeMax:
+	default:
+		return domain.Heatmap{}, fmt.Errorf("normalize must be none, total or max")
+	}
+
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	counts := map[string]*[7][24]int{
+		personOne: {},
+		personTwo: {},
+		"overall": {},
+	}
+
+	for _, message := range chat.Messages {
+		if isServiceMessage(message) || (message.From != personOne && message.From != personTwo) {
+			continue
+		}
+		sentAt, err := messageTime(message, opts.Location)
+		if err != nil {
+			continue
+		}
+		row := (int(sentAt.Weekday()) - int(opts.WeekStart) + 7) % 7
+		counts[message.From][row][sentAt.Hour()]++
+		counts["overall"][row][sentAt.Hour()]++
+	}
+
+	heatmap := domain.Heatmap{
+		Normalize: opts.Normalize,
+		Grids:     make(map[string][][]float64),
+		Peaks:     make(map[string]domain.HeatmapPeak),
+	}
+	for row := 0; row < 7; row++ {
+		weekday := time.Weekday((int(opts.WeekStart) + row) % 7)
+		heatmap.Days = append(heatmap.Days, strings.ToLower(weekday.String()))
+	}
+
+	for participant, grid := range counts {
+		total := 0
+		var peak domain.HeatmapPeak
+		for row := range grid {
+			for hour, count := range grid[row] {
+				total += count
+				if count > peak.Messages {
+					peak = domain.HeatmapPeak{Day: heatmap.Days[row], Hour: hour, Messages: count}
+				}
+			}
+		}
+
+		cells := make([][]float64, 7)
+		for row := range grid {
+			cells[row] = make([]float64, 24)
+			for hour, count := range grid[row] {
+				value := float64(count)
+				switch {
+				case opts.Normalize == NormalizeTotal && total > 0:
+					value = roundTo(value/float64(total), 4)
+				case opts.Normalize == NormalizeMax && peak.Messages > 0:
+					value = roundTo(value/float64(peak.Messages), 4)
+				}
+				cells[row][hour] = value
+			}
+		}
+		heatmap.Grids[participant] = cells
+		heatmap.Peaks[participant] = peak
+	}
+	return heatmap, nil
+}
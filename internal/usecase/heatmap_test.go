@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityHeatmapNormalize(t *testing.T) {
+	// 2024-01-01 is a Monday.
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "morning").
+		Add(date(2024, 1, 1, 9, 30), "Alice", "still there?").
+		Add(date(2024, 1, 1, 9, 45), "Bob", "yes").
+		Add(date(2024, 1, 3, 21, 0), "Alice", "night")
+
+	tests := []struct {
+		normalize string
+		monday    float64
+		wednesday float64
+	}{
+		{NormalizeNone, 2, 1},
+		{NormalizeTotal, 0.6667, 0.3333},
+		{NormalizeMax, 1, 0.5},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.normalize, func(t *testing.T) {
+			heatmap, err := u.ActivityHeatmap(chat.Chat, HeatmapOptions{WeekStart: time.Monday, Normalize: tt.normalize})
+			if err != nil {
+				t.Fatal(err)
+			}
+			grid := heatmap.Grids["Alice"]
+			if grid[0][9] != tt.monday || grid[2][21] != tt.wednesday {
+				t.Errorf("Alice's Monday 9h = %v, Wednesday 21h = %v; want %v and %v", grid[0][9], grid[2][21], tt.monday, tt.wednesday)
+			}
+			if peak := heatmap.Peaks["Alice"]; peak.Day != "monday" || peak.Hour != 9 || peak.Messages != 2 {
+				t.Errorf("Alice's peak = %+v; want monday 9h with 2 messages", peak)
+			}
+			if got := heatmap.Grids["overall"][0][9]; tt.normalize == NormalizeNone && got != 3 {
+				t.Errorf("overall Monday 9h = %v; want 3", got)
+			}
+		})
+	}
+}
+
+func TestActivityHeatmapWeekStart(t *testing.T) {
+	// 2024-01-07 is a Sunday.
+	chat := newTestChat().Add(date(2024, 1, 7, 12, 0), "Bob", "lunch?")
+
+	tests := []struct {
+		weekStart time.Weekday
+		first     string
+		sundayRow int
+	}{
+		{time.Monday, "monday", 6},
+		{time.Sunday, "sunday", 0},
+		{time.Saturday, "saturday", 1},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.first, func(t *testing.T) {
+			heatmap, err := u.ActivityHeatmap(chat.Chat, HeatmapOptions{WeekStart: tt.weekStart, Normalize: NormalizeNone})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(heatmap.Days) != 7 || heatmap.Days[0] != tt.first || heatmap.Days[tt.sundayRow] != "sunday" {
+				t.Fatalf("days = %v; want %s first and sunday in row %d", heatmap.Days, tt.first, tt.sundayRow)
+			}
+			if got := heatmap.Grids["Bob"][tt.sundayRow][12]; got != 1 {
+				t.Errorf("Bob's Sunday noon = %v; want 1", got)
+			}
+		})
+	}
+}
+
+func TestActivityHeatmapRejectsUnknownNormalize(t *testing.T) {
+	_, err := newTestUsecase(t).ActivityHeatmap(newTestChat().Chat, HeatmapOptions{Normalize: "percent"})
+	if err == nil {
+		t.Fatal("expected an error for normalize=percent")
+	}
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+// testChat builds a chat between Alice (user1) and Bob (user2, the chat
+// name). Add appends messages and Service appends service entries such as
+// calls.
+type testChat struct {
+	domain.Chat
+}
+
+func newTestChat() *testChat {
+	return &testChat{Chat: domain.Chat{Name: "Bob", ID: 2}}
+}
+
+func (c *testChat) Add(at time.Time, from, text string) *testChat {
+	fromID := "user1"
+	if from == "Bob" {
+		fromID = "user2"
+	}
+	c.Messages = append(c.Messages, domain.Message{
+		ID:     len(c.Messages) + 1,
+		Type:   "message",
+		Date:   at.Format("2006-01-02T15:04:05"),
+		From:   from,
+		FromID: fromID,
+		Text:   text,
+	})
+	return c
+}
+
+func (c *testChat) Service(at time.Time, action string, durationSeconds int) *testChat {
+	c.Messages = append(c.Messages, domain.Message{
+		ID:              len(c.Messages) + 1,
+		Type:            "service",
+		Date:            at.Format("2006-01-02T15:04:05"),
+		Action:          action,
+		DurationSeconds: durationSeconds,
+	})
+	return c
+}
+
+func newTestUsecase(t *testing.T) *messageUsecase {
+	t.Helper()
+	return NewMessageUsecase(Config{}).(*messageUsecase)
+}
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
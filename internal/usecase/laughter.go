@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"regexp"
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// LaughterOptions controls Laughter.
+type LaughterOptions struct {
+	// Location is the timezone used for sessions and months. Nil keeps the export's wall clock.
+	Location *time.Location
+	// SessionGap is how far back a laugh may be attributed to the other person's message.
+	SessionGap time.Duration
+	// Top is how many funniest messages to return.
+	Top int
+}
+
+// laughterPattern matches whole word tokens that are laughter in the scripts
+// and chat conventions we see: haha, hehe, ahah, jaja, lol, lmao, rofl, xd,
+// хаха, ሃሃ, ههه and ㅋㅋ. A single "ha" counts after a leading "a" only when
+// another "h" follows, so "ahah" laughs and "aha" does not. Thai 555 and
+// Japanese www are left out because they collide with numbers and links.
+var laughterPattern = regexp.MustCompile(`^(?:` +
+	`a?(?:h+[aeiou]+){2,}h*|a(?:h+[aeiou]+)+h+|(?:j+[aei]+){2,}j*|l+o+l+|lmf?a+o+|rofl|x+d+|` +
+	`а?(?:х+[аеи]+){2,}х*|а(?:х+[аеи]+)+х+|` +
+	`[ሀሃሐሓኸ]{2,}|ኪ{2,}|` +
+	`ه{3,}|[ㅋㅎ]{2,}` +
+	`)$`)
+
+var laughterEmoji = map[string]bool{"😂": true, "🤣": true, "😆": true, "😹": true}
+
+// Laughter detects laughing messages and attributes each one to the most
+// recent message of the other participant in the same session, giving who
+// makes whom laugh, the funniest messages and the monthly laughter rate.
+// Laughing at the same message again adds to its intensity but is not
+// counted as another laugh.
+func (u *messageUsecase) Laughter(chat domain.Chat, opts LaughterOptions) (domain.LaughterReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	report := domain.LaughterReport{
+		People:   make(map[string]domain.LaughterStats, len(participants)),
+		Funniest: []domain.FunnyMessage{},
+		Timeline: domain.LaughterTimeline{
+			Periods: []string{},
+			Laughs:  make(map[string][]int, len(participants)),
+			Rate:    make(map[string][]float64, len(participants)),
+		},
+	}
+	people := map[string]domain.LaughterStats{personOne: {}, personTwo: {}}
+
+	messages := u.sortedMessages(chat, opts.Location)
+	if len(messages) == 0 {
+		for _, person := range participants {
+			report.People[person] = people[person]
+			report.Timeline.Laughs[person] = []int{}
+			report.Timeline.Rate[person] = []float64{}
+		}
+		return report, nil
+	}
+
+	first := periodStart(messages[0].At, GranularityMonth, time.Monday)
+	last := periodStart(messages[len(messages)-1].At, GranularityMonth, time.Monday)
+	monthIndex := make(map[string]int)
+	for month := first; !month.After(last); month = nextPeriod(month, GranularityMonth, 1) {
+		key := month.Format(dateLayout)
+		monthIndex[key] = len(report.Timeline.Periods)
+		report.Timeline.Periods = append(report.Timeline.Periods, key)
+	}
+	monthMessages := make(map[string][]int, len(participants))
+	monthLaughs := make(map[string][]int, len(participants))
+	for _, person := range participants {
+		monthMessages[person] = make([]int, len(report.Timeline.Periods))
+		monthLaughs[person] = make([]int, len(report.Timeline.Periods))
+	}
+
+	// funny is keyed by the joke's position in the sorted messages.
+	funny := make(map[int]*domain.FunnyMessage)
+	offset := 0
+	for _, s := range splitSessions(messages, opts.SessionGap) {
+		// lastFrom holds the position in the session of each participant's
+		// latest message that was not itself laughter; laughing along with a
+		// laugh does not make the first laugh a joke.
+		lastFrom := make(map[string]int)
+		// laughedAt holds the jokes in the session someone has laughed at.
+		laughedAt := make(map[int]bool)
+		for i, message := range s.Messages {
+			month := monthIndex[periodStart(message.At, GranularityMonth, time.Monday).Format(dateLayout)]
+			monthMessages[message.From][month]++
+			stats := people[message.From]
+			stats.Messages++
+
+			intensity := laughterIntensity(messageText(message.Message))
+			if intensity > 0 {
+				stats.Laughs++
+				monthLaughs[message.From][month]++
+
+				for _, other := range participants {
+					j, ok := lastFrom[other]
+					if other == message.From || !ok {
+						continue
+					}
+					joke := s.Messages[j]
+					key := offset + j
+					if funny[key] == nil {
+						funny[key] = &domain.FunnyMessage{
+							ID:   joke.ID,
+							From: joke.From,
+							Date: joke.At.Format(dateTimeLayout),
+							Text: messageText(joke.Message),
+						}
+					}
+					funny[key].Intensity += intensity
+					if laughedAt[j] {
+						continue
+					}
+					laughedAt[j] = true
+					funny[key].Laughs++
+					otherStats := people[other]
+					otherStats.MadeLaugh++
+					people[other] = otherStats
+				}
+			}
+			people[message.From] = stats
+			if intensity == 0 {
+				lastFrom[message.From] = i
+			}
+		}
+		offset += len(s.Messages)
+	}
+
+	for _, person := range participants {
+		stats := people[person]
+		if stats.Messages > 0 {
+			stats.LaughRate = roundTo(float64(stats.Laughs)/float64(stats.Messages), 4)
+		}
+		report.People[person] = stats
+
+		rates := make([]float64, len(report.Timeline.Periods))
+		for m, total := range monthMessages[person] {
+			if total > 0 {
+				rates[m] = roundTo(float64(monthLaughs[person][m])/float64(total), 4)
+			}
+		}
+		report.Timeline.Laughs[person] = monthLaughs[person]
+		report.Timeline.Rate[person] = rates
+	}
+
+	for _, message := range funny {
+		report.Funniest = append(report.Funniest, *message)
+	}
+	sort.Slice(report.Funniest, func(i, j int) bool {
+		a, b := report.Funniest[i], report.Funniest[j]
+		if a.Laughs != b.Laughs {
+			return a.Laughs > b.Laughs
+		}
+		if a.Intensity != b.Intensity {
+			return a.Intensity > b.Intensity
+		}
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return a.ID < b.ID
+	})
+	if len(report.Funniest) > opts.Top {
+		report.Funniest = report.Funniest[:opts.Top]
+	}
+	return report, nil
+}
+
+// laughterIntensity counts the laughter tokens in text, so "hahaha 😂😂"
+// scores higher than a lone "lol". Zero means the message is not laughter.
+func laughterIntensity(text string) int {
+	intensity := 0
+	for _, token := range wordsAndEmoji(text) {
+		if laughterEmoji[token] || laughterPattern.MatchString(token) {
+			intensity++
+		}
+	}
+	return intensity
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLaughterIntensity(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"haha", 1},
+		{"ahaha", 1},
+		{"ahah", 1},
+		{"ahahah", 1},
+		{"ахах", 1},
+		{"HAHAHA that's great", 1},
+		{"hehe lol 😂😂", 4},
+		{"jajaja", 1},
+		{"lmao", 1},
+		{"xD", 1},
+		{"хахаха", 1},
+		{"ㅋㅋㅋ", 1},
+		{"ha", 0},
+		{"aha", 0},
+		{"hello", 0},
+		{"ahead", 0},
+		{"555", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := laughterIntensity(tt.text); got != tt.want {
+				t.Errorf("laughterIntensity(%q) = %d; want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLaughterAttributesToOtherPerson(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 20, 0), "Bob", "knock knock").
+		Add(date(2024, 1, 1, 20, 1), "Alice", "hahaha").
+		Add(date(2024, 1, 1, 20, 2), "Bob", "lol").
+		Add(date(2024, 1, 2, 20, 0), "Alice", "haha")
+
+	report, err := newTestUsecase(t).Laughter(chat.Chat, LaughterOptions{SessionGap: time.Hour, Top: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bob laughing along does not make Alice's laugh a joke, and Alice's
+	// laugh the next day has nothing in its session to laugh at.
+	if bob := report.People["Bob"]; bob.MadeLaugh != 1 || bob.Laughs != 1 {
+		t.Errorf("Bob = %+v; want 1 laugh made and 1 laugh", bob)
+	}
+	if alice := report.People["Alice"]; alice.MadeLaugh != 0 || alice.Laughs != 2 || alice.LaughRate != 1 {
+		t.Errorf("Alice = %+v; want no laughs made, 2 laughs and a rate of 1", alice)
+	}
+	if len(report.Funniest) != 1 || report.Funniest[0].Text != "knock knock" {
+		t.Errorf("funniest = %+v; want only knock knock", report.Funniest)
+	}
+}
+
+func TestLaughterCountsOneLaughPerJoke(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 20, 0), "Bob", "knock knock").
+		Add(date(2024, 1, 1, 20, 1), "Alice", "hahaha").
+		Add(date(2024, 1, 1, 20, 2), "Alice", "lol 😂")
+
+	report, err := newTestUsecase(t).Laughter(chat.Chat, LaughterOptions{SessionGap: time.Hour, Top: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bob := report.People["Bob"]; bob.MadeLaugh != 1 {
+		t.Errorf("Bob = %+v; want 1 laugh made", bob)
+	}
+	if alice := report.People["Alice"]; alice.Laughs != 2 {
+		t.Errorf("Alice = %+v; want 2 laughing messages", alice)
+	}
+	if len(report.Funniest) != 1 || report.Funniest[0].Laughs != 1 || report.Funniest[0].Intensity != 3 {
+		t.Errorf("funniest = %+v; want knock knock with 1 laugh of intensity 3", report.Funniest)
+	}
+}
+
+func TestLaughterFunniestDateInLocation(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 23, 30), "Bob", "knock knock").
+		Add(date(2024, 1, 1, 23, 31), "Alice", "haha")
+	for i := range chat.Messages {
+		chat.Messages[i].DateUnixtime = strconv.FormatInt(date(2024, 1, 1, 23, 30+i).Unix(), 10)
+	}
+
+	report, err := newTestUsecase(t).Laughter(chat.Chat, LaughterOptions{Location: time.FixedZone("UTC+2", 2*60*60), SessionGap: time.Hour, Top: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Funniest) != 1 || report.Funniest[0].Date != "2024-01-02T01:30:00" {
+		t.Errorf("funniest = %+v; want knock knock at 01:30 on January 2", report.Funniest)
+	}
+}
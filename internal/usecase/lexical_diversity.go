@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+const (
+	// mtldThreshold is the type-token ratio at which MTLD closes a factor,
+	// the value recommended by McCarthy and Jarvis.
+	mtldThreshold = 0.72
+	// msttrSegment is the number of tokens in each MSTTR segment.
+	msttrSegment = 100
+)
+
+// LexicalDiversity measures each participant's vocabulary size, type-token
+// ratio, length-robust diversity (MTLD and MSTTR) and hapax legomena, and
+// traces how many new words each of them introduced month by month.
+func (u *messageUsecase) LexicalDiversity(chat domain.Chat, loc *time.Location) (domain.LexicalDiversityReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	report := domain.LexicalDiversityReport{
+		People: make(map[string]domain.LexicalStats, len(participants)),
+		Growth: domain.VocabularyGrowth{
+			Periods:        []string{},
+			NewWords:       make(map[string][]int, len(participants)),
+			VocabularySize: make(map[string][]int, len(participants)),
+		},
+	}
+
+	messages := u.sortedMessages(chat, loc)
+	tokens := make(map[string][]string, len(participants))
+	firstUse := make(map[string]map[string]time.Time, len(participants))
+	for _, person := range participants {
+		firstUse[person] = make(map[string]time.Time)
+	}
+	for _, message := range messages {
+		for _, word := range wordTokens(messageText(message.Message)) {
+			if isNumeric(word) {
+				continue
+			}
+			tokens[message.From] = append(tokens[message.From], word)
+			if _, seen := firstUse[message.From][word]; !seen {
+				firstUse[message.From][word] = message.At
+			}
+		}
+	}
+
+	for _, person := range participants {
+		report.People[person] = lexicalStats(tokens[person])
+	}
+	one, two := report.People[personOne].MTLD, report.People[personTwo].MTLD
+	if one > two {
+		report.Richest = personOne
+	} else if two > one {
+		report.Richest = personTwo
+	}
+
+	if len(messages) == 0 {
+		for _, person := range participants {
+			report.Growth.NewWords[person] = []int{}
+			report.Growth.VocabularySize[person] = []int{}
+		}
+		return report, nil
+	}
+
+	first := periodStart(messages[0].At, GranularityMonth, time.Monday)
+	last := periodStart(messages[len(messages)-1].At, GranularityMonth, time.Monday)
+	monthIndex := make(map[string]int)
+	for month := first; !month.After(last); month = nextPeriod(month, GranularityMonth, 1) {
+		key := month.Format(dateLayout)
+		monthIndex[key] = len(report.Growth.Periods)
+		report.Growth.Periods = append(report.Growth.Periods, key)
+	}
+	for _, person := range participants {
+		newWords := make([]int, len(report.Growth.Periods))
+		for _, at := range firstUse[person] {
+			newWords[monthIndex[periodStart(at, GranularityMonth, time.Monday).Format(dateLayout)]]++
+		}
+		report.Growth.NewWords[person] = newWords
+		report.Growth.VocabularySize[person] = cumulativeSum(newWords)
+	}
+	return report, nil
+}
+
+func lexicalStats(tokens []string) domain.LexicalStats {
+	counts := make(map[string]int)
+	for _, token := range tokens {
+		counts[token]++
+	}
+	stats := domain.LexicalStats{
+		Tokens:         len(tokens),
+		VocabularySize: len(counts),
+	}
+	if len(tokens) == 0 {
+		return stats
+	}
+	for _, count := range counts {
+		if count == 1 {
+			stats.Hapax++
+		}
+	}
+	stats.TypeTokenRatio = roundTo(float64(len(counts))/float64(len(tokens)), 4)
+	stats.HapaxRatio = roundTo(float64(stats.Hapax)/float64(len(counts)), 4)
+	stats.MSTTR = roundTo(msttr(tokens), 4)
+
+	reversed := make([]string, len(tokens))
+	for i, token := range tokens {
+		reversed[len(tokens)-1-i] = token
+	}
+	stats.MTLD = roundTo((mtldPass(tokens)+mtldPass(reversed))/2, 2)
+	return stats
+}
+
+// mtldPass counts how many times the running type-token ratio falls to the
+// threshold, credits the unfinished remainder as a partial factor, and
+// divides the token count by the number of factors.
+func mtldPass(tokens []string) float64 {
+	factors := 0.0
+	types := make(map[string]bool)
+	count := 0
+	for _, token := range tokens {
+		types[token] = true
+		count++
+		if float64(len(types))/float64(count) <= mtldThreshold {
+			factors++
+			types = make(map[string]bool)
+			count = 0
+		}
+	}
+	if count > 0 {
+		ratio := float64(len(types)) / float64(count)
+		factors += (1 - ratio) / (1 - mtldThreshold)
+	}
+	if factors == 0 {
+		return float64(len(tokens))
+	}
+	return float64(len(tokens)) / factors
+}
+
+// msttr averages the type-token ratio of consecutive full segments of
+// msttrSegment tokens. Texts shorter than one segment use their plain ratio.
+func msttr(tokens []string) float64 {
+	segments := len(tokens) / msttrSegment
+	if segments == 0 {
+		types := make(map[string]bool)
+		for _, token := range tokens {
+			types[token] = true
+		}
+		return float64(len(types)) / float64(len(tokens))
+	}
+	sum := 0.0
+	for s := 0; s < segments; s++ {
+		types := make(map[string]bool)
+		for _, token := range tokens[s*msttrSegment : (s+1)*msttrSegment] {
+			types[token] = true
+		}
+		sum += float64(len(types)) / msttrSegment
+	}
+	return sum / float64(segments)
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMTLDPass(t *testing.T) {
+	distinct := make([]string, 10)
+	for i := range distinct {
+		distinct[i] = "w" + strconv.Itoa(i)
+	}
+	tests := []struct {
+		name   string
+		tokens []string
+		want   float64
+	}{
+		{name: "one repeated word closes a factor every two tokens", tokens: strings.Fields("a a a a a a a a a a"), want: 2},
+		{name: "all distinct never closes a factor", tokens: distinct, want: 10},
+		// a b a: ratio 2/3 <= 0.72 closes a factor; "b c" remains at ratio 1.
+		{name: "remainder at full ratio adds nothing", tokens: strings.Fields("a b a b c"), want: 5},
+		// "a b a" closes a factor; "c d e c" is left at ratio 0.75.
+		{name: "partial factor", tokens: strings.Fields("a b a c d e c"), want: 7 / (1 + (1-0.75)/(1-mtldThreshold))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mtldPass(tt.tokens); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("mtldPass = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMSTTR(t *testing.T) {
+	tokens := make([]string, 0, 2*msttrSegment+10)
+	for i := 0; i < msttrSegment; i++ {
+		tokens = append(tokens, "w"+strconv.Itoa(i))
+	}
+	for i := 0; i < msttrSegment+10; i++ {
+		tokens = append(tokens, "same")
+	}
+	// The trailing partial segment is ignored.
+	want := (1 + 1.0/msttrSegment) / 2
+	if got := msttr(tokens); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("msttr = %v; want %v", got, want)
+	}
+	if got := msttr(strings.Fields("a b a")); math.Abs(got-2.0/3) > 1e-9 {
+		t.Fatalf("msttr of a short text = %v; want its type-token ratio", got)
+	}
+}
+
+func TestLexicalStats(t *testing.T) {
+	stats := lexicalStats(strings.Fields("a b a"))
+	if stats.Tokens != 3 || stats.VocabularySize != 2 || stats.Hapax != 1 || stats.TypeTokenRatio != 0.6667 || stats.HapaxRatio != 0.5 {
+		t.Fatalf("lexicalStats = %+v", stats)
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+)
+
+// Lexicon scores single tokens for sentiment. Tokens are lowercased words or
+// single emoji as produced by wordsAndEmoji.
+type Lexicon interface {
+	Language() string
+	Score(token string) (float64, bool)
+	IsNegator(token string) bool
+}
+
+type mapLexicon struct {
+	language string
+	words    map[string]float64
+	negators map[string]bool
+}
+
+// NewLexicon builds a Lexicon from word scores and negators.
+func NewLexicon(language string, data domain.LexiconData) Lexicon {
+	lexicon := &mapLexicon{
+		language: language,
+		words:    make(map[string]float64, len(data.Words)),
+		negators: make(map[string]bool, len(data.Negators)),
+	}
+	for word, score := range data.Words {
+		lexicon.words[strings.ToLower(word)] = score
+	}
+	for _, negator := range data.Negators {
+		lexicon.negators[strings.ToLower(negator)] = true
+	}
+	return lexicon
+}
+
+func (l *mapLexicon) Language() string {
+	return l.language
+}
+
+func (l *mapLexicon) Score(token string) (float64, bool) {
+	score, ok := l.words[token]
+	return score, ok
+}
+
+func (l *mapLexicon) IsNegator(token string) bool {
+	return l.negators[token]
+}
+
+func builtinLexicons() map[string]Lexicon {
+	return map[string]Lexicon{
+		"en":    NewLexicon("en", englishLexicon),
+		"am":    NewLexicon("am", amharicLexicon),
+		"emoji": NewLexicon("emoji", emojiLexicon),
+	}
+}
+
+var englishLexicon = domain.LexiconData{
+	Words: map[string]float64{
+		"love": 3, "loved": 3, "loving": 3, "lovely": 3, "adore": 3,
+		"happy": 3, "glad": 2, "joy": 3, "yay": 3, "excited": 3,
+		"good": 2, "great": 3, "awesome": 4, "amazing": 4, "wonderful": 4,
+		"excellent": 3, "perfect": 3, "best": 3, "nice": 2, "cool": 1,
+		"beautiful": 3, "cute": 2, "sweet": 2, "pretty": 2, "gorgeous": 3,
+		"thanks": 2, "thank": 2, "grateful": 3, "proud": 2, "kind": 2,
+		"fun": 2, "funny": 2, "haha": 2, "lol": 2, "wow": 2,
+		"hope": 1, "fine": 1, "okay": 1, "win": 2, "congrats": 3,
+		"bad": -3, "worse": -3, "worst": -3, "awful": -3, "terrible": -3,
+		"sad": -2, "unhappy": -2, "cry": -2, "crying": -2, "upset": -2,
+		"hate": -3, "hated": -3, "angry": -3, "mad": -3, "annoyed": -2,
+		"annoying": -2, "boring": -2, "bored": -2, "tired": -1, "sick": -2,
+		"hurt": -2, "pain": -2, "lonely": -2, "worried": -2, "scared": -2,
+		"afraid": -2, "sorry": -1, "stupid": -2, "ugly": -3, "disappointed": -2,
+		"fail": -2, "failed": -2, "wrong": -2, "problem": -1, "stress": -2,
+	},
+	Negators: []string{
+		"not", "no", "never", "nothing", "neither",
+		"don't", "dont", "doesn't", "didn't", "isn't", "isnt",
+		"wasn't", "aren't", "can't", "cant", "won't", "wont",
+	},
+}
+
+var amharicLexicon = domain.LexiconData{
+	Words: map[string]float64{
+		"ፍቅር": 3, "እወድሻለሁ": 3, "እወድሃለሁ": 3, "ደስ": 2, "ደስተኛ": 3,
+		"ጥሩ": 2, "ቆንጆ": 3, "ምርጥ": 3, "ጎበዝ": 2, "አሪፍ": 2,
+		"አመሰግናለሁ": 2, "እናመሰግናለን": 2, "ሰላም": 1, "እንኳን": 1, "ውዴ": 3,
+		"መጥፎ": -3, "ክፉ": -3, "አዝናለሁ": -2, "ያሳዝናል": -2, "ተናድጃለሁ": -3,
+		"ደክሞኛል": -1, "ጠላሁ": -3, "ችግር": -1, "ታምሜ": -2, "አስጠላኝ": -3,
+	},
+}
+
+var emojiLexicon = domain.LexiconData{
+	Words: map[string]float64{
+		"😂": 2, "🤣": 2, "😀": 2, "😃": 2, "😄": 2, "😁": 2, "😅": 1, "😊": 2,
+		"🙂": 1, "😉": 1, "😍": 3, "🥰": 3, "😘": 3, "❤": 3, "💕": 3, "💖": 3,
+		"💗": 3, "💘": 3, "😻": 3, "👍": 2, "🙏": 1, "🔥": 2, "🎉": 3, "🥳": 3,
+		"😢": -2, "😭": -2, "😞": -2, "😔": -2, "😟": -2, "🙁": -2, "☹": -2,
+		"😕": -1, "😒": -2, "🙄": -1, "😩": -2, "😫": -2, "😤": -2, "😠": -3,
+		"😡": -3, "🤬": -4, "💔": -3, "👎": -2,
+	},
+}
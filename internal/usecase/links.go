@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// LinkOptions controls Links.
+type LinkOptions struct {
+	// Location is the timezone used for months and share dates. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Top is how many domains and repeated links to return.
+	Top int
+}
+
+// Link categories.
+const (
+	LinkCategoryVideo  = "video"
+	LinkCategorySocial = "social"
+	LinkCategoryMusic  = "music"
+	LinkCategoryNews   = "news"
+	LinkCategoryOther  = "other"
+)
+
+var linkCategories = map[string]string{
+	"youtube.com": LinkCategoryVideo, "vimeo.com": LinkCategoryVideo, "twitch.tv": LinkCategoryVideo,
+	"netflix.com": LinkCategoryVideo, "tiktok.com": LinkCategoryVideo,
+	"instagram.com": LinkCategorySocial, "facebook.com": LinkCategorySocial, "twitter.com": LinkCategorySocial,
+	"x.com": LinkCategorySocial, "reddit.com": LinkCategorySocial, "t.me": LinkCategorySocial,
+	"linkedin.com": LinkCategorySocial, "pinterest.com": LinkCategorySocial, "threads.net": LinkCategorySocial,
+	"spotify.com": LinkCategoryMusic, "music.apple.com": LinkCategoryMusic, "soundcloud.com": LinkCategoryMusic,
+	"music.youtube.com": LinkCategoryMusic, "deezer.com": LinkCategoryMusic,
+	"bbc.com": LinkCategoryNews, "bbc.co.uk": LinkCategoryNews, "cnn.com": LinkCategoryNews,
+	"nytimes.com": LinkCategoryNews, "theguardian.com": LinkCategoryNews, "reuters.com": LinkCategoryNews,
+	"aljazeera.com": LinkCategoryNews, "apnews.com": LinkCategoryNews, "washingtonpost.com": LinkCategoryNews,
+	"bloomberg.com": LinkCategoryNews, "news.google.com": LinkCategoryNews,
+}
+
+// domainAliases folds short links and alternate hosts into one domain.
+var domainAliases = map[string]string{
+	"youtu.be": "youtube.com", "fb.com": "facebook.com", "fb.watch": "facebook.com",
+	"vm.tiktok.com": "tiktok.com", "instagr.am": "instagram.com", "open.spotify.com": "spotify.com",
+	"mobile.twitter.com": "twitter.com", "telegram.me": "t.me",
+}
+
+// mobileMirrors are sites whose "m." host serves the same pages as the main
+// one. Other "m." hosts, such as Messenger's m.me, are left alone.
+var mobileMirrors = map[string]bool{
+	"youtube.com": true, "facebook.com": true, "twitter.com": true, "x.com": true, "reddit.com": true,
+	"tiktok.com": true, "twitch.tv": true, "imdb.com": true, "vk.com": true, "aliexpress.com": true,
+	"wikipedia.org": true, "wiktionary.org": true,
+}
+
+// trackingParams are query parameters that only identify who shared a link
+// or where it was clicked; they are removed so equal links compare equal.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true, "igshid": true,
+	"igsh": true, "si": true, "feature": true, "ref": true, "ref_src": true, "ref_url": true,
+	"mc_cid": true, "mc_eid": true, "_ga": true, "spm": true, "share_id": true, "is_from_webapp": true,
+	"sender_device": true,
+}
+
+// Links reads link and text_link entities and reports the links each
+// participant shared, the most linked domains, links shared more than once
+// and monthly link-sharing counts. URLs are normalized before comparison.
+func (u *messageUsecase) Links(chat domain.Chat, opts LinkOptions) (domain.LinkReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	type share struct {
+		url    string
+		domain string
+		from   string
+		at     time.Time
+	}
+	var shares []share
+	messages := u.sortedMessages(chat, opts.Location)
+	for _, message := range messages {
+		for _, entity := range messageEntities(message.Message) {
+			raw := entity.Text
+			if entity.Type == "text_link" {
+				raw = entity.Href
+			} else if entity.Type != "link" {
+				continue
+			}
+			normalized, host, ok := normalizeURL(raw)
+			if !ok {
+				continue
+			}
+			shares = append(shares, share{url: normalized, domain: host, from: message.From, at: message.At})
+		}
+	}
+
+	report := domain.LinkReport{
+		People:     make(map[string]domain.LinkStats, len(participants)),
+		TopDomains: []domain.DomainCount{},
+		Repeated:   []domain.RepeatedLink{},
+		Timeline:   domain.LinkTimeline{Periods: []string{}, Links: make(map[string][]int, len(participants))},
+	}
+
+	unique := map[string]map[string]bool{personOne: {}, personTwo: {}}
+	domains := make(map[string]*domain.DomainCount)
+	links := make(map[string]*domain.RepeatedLink)
+	for _, person := range participants {
+		report.People[person] = domain.LinkStats{Categories: make(map[string]int)}
+	}
+	for _, s := range shares {
+		stats := report.People[s.from]
+		stats.Links++
+		stats.Categories[linkCategory(s.domain)]++
+		unique[s.from][s.url] = true
+		stats.UniqueLinks = len(unique[s.from])
+		report.People[s.from] = stats
+
+		if domains[s.domain] == nil {
+			domains[s.domain] = &domain.DomainCount{
+				Domain:   s.domain,
+				Category: linkCategory(s.domain),
+				Counts:   map[string]int{personOne: 0, personTwo: 0},
+			}
+		}
+		domains[s.domain].Total++
+		domains[s.domain].Counts[s.from]++
+
+		date := s.at.Format(dateLayout)
+		if links[s.url] == nil {
+			links[s.url] = &domain.RepeatedLink{
+				URL:         s.url,
+				Counts:      map[string]int{personOne: 0, personTwo: 0},
+				FirstShared: date,
+			}
+		}
+		links[s.url].Total++
+		links[s.url].Counts[s.from]++
+		links[s.url].LastShared = date
+	}
+
+	for _, count := range domains {
+		report.TopDomains = append(report.TopDomains, *count)
+	}
+	sort.Slice(report.TopDomains, func(i, j int) bool {
+		if report.TopDomains[i].Total != report.TopDomains[j].Total {
+			return report.TopDomains[i].Total > report.TopDomains[j].Total
+		}
+		return report.TopDomains[i].Domain < report.TopDomains[j].Domain
+	})
+	if len(report.TopDomains) > opts.Top {
+		report.TopDomains = report.TopDomains[:opts.Top]
+	}
+
+	for _, link := range links {
+		if link.Total > 1 {
+			report.Repeated = append(report.Repeated, *link)
+		}
+	}
+	sort.Slice(report.Repeated, func(i, j int) bool {
+		if report.Repeated[i].Total != report.Repeated[j].Total {
+			return report.Repeated[i].Total > report.Repeated[j].Total
+		}
+		return report.Repeated[i].URL < report.Repeated[j].URL
+	})
+	if len(report.Repeated) > opts.Top {
+		report.Repeated = report.Repeated[:opts.Top]
+	}
+
+	for _, person := range participants {
+		report.Timeline.Links[person] = []int{}
+	}
+	if len(messages) == 0 {
+		return report, nil
+	}
+	first := periodStart(messages[0].At, GranularityMonth, time.Monday)
+	last := periodStart(messages[len(messages)-1].At, GranularityMonth, time.Monday)
+	monthIndex := make(map[string]int)
+	for month := first; !month.After(last); month = nextPeriod(month, GranularityMonth, 1) {
+		key := month.Format(dateLayout)
+		monthIndex[key] = len(report.Timeline.Periods)
+		report.Timeline.Periods = append(report.Timeline.Periods, key)
+	}
+	for _, person := range participants {
+		report.Timeline.Links[person] = make([]int, len(report.Timeline.Periods))
+	}
+	for _, s := range shares {
+		report.Timeline.Links[s.from][monthIndex[periodStart(s.at, GranularityMonth, time.Monday).Format(dateLayout)]]++
+	}
+	return report, nil
+}
+
+// normalizeURL makes equal links compare equal: it assumes https when the
+// scheme is missing, lowercases the host, drops "www." and the "m." of known
+// mobile mirrors, folds known short-link hosts, removes tracking parameters
+// and fragments, sorts the remaining query and trims a trailing slash. It
+// returns the URL and its domain.
+func normalizeURL(raw string) (string, string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", false
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return "", "", false
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", "", false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	host = desktopHost(host)
+	path := parsed.Path
+	query := parsed.Query()
+	if host == "youtu.be" && len(path) > 1 {
+		query.Set("v", strings.Trim(path, "/"))
+		path = "/watch"
+	}
+	if alias, ok := domainAliases[host]; ok {
+		host = alias
+	}
+
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	path = strings.TrimRight(path, "/")
+
+	normalized := url.URL{Scheme: "https", Host: host, Path: path, RawQuery: query.Encode()}
+	return normalized.String(), host, true
+}
+
+// desktopHost maps a mobile mirror such as m.youtube.com or en.m.wikipedia.org
+// to its main host.
+func desktopHost(host string) string {
+	if strings.HasPrefix(host, "m.") && mobileMirrors[host[len("m."):]] {
+		return host[len("m."):]
+	}
+	if i := strings.Index(host, ".m."); i >= 0 && mobileMirrors[host[i+len(".m."):]] {
+		return host[:i] + host[i+len(".m"):]
+	}
+	return host
+}
+
+// linkCategory looks up the domain and then each parent domain, so
+// "edition.cnn.com" is news like "cnn.com".
+func linkCategory(host string) string {
+	for {
+		if category, ok := linkCategories[host]; ok {
+			return category
+		}
+		dot := strings.Index(host, ".")
+		if dot < 0 || !strings.Contains(host[dot+1:], ".") {
+			return LinkCategoryOther
+		}
+		host = host[dot+1:]
+	}
+}
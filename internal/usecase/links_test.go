@@ -0,0 +1,33 @@
+package usecase
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		raw    string
+		url    string
+		domain string
+		ok     bool
+	}{
+		{"example.com/page/", "https://example.com/page", "example.com", true},
+		{"HTTP://WWW.Example.com/a", "https://example.com/a", "example.com", true},
+		{"https://m.youtube.com/watch?v=abc&feature=share", "https://youtube.com/watch?v=abc", "youtube.com", true},
+		{"https://youtu.be/abc?si=xyz", "https://youtube.com/watch?v=abc", "youtube.com", true},
+		{"https://en.m.wikipedia.org/wiki/Go", "https://en.wikipedia.org/wiki/Go", "en.wikipedia.org", true},
+		{"https://m.me/someone", "https://m.me/someone", "m.me", true},
+		{"https://m.example.org/", "https://m.example.org", "m.example.org", true},
+		{"https://shop.com/item?utm_source=tg&UTM_Medium=x&fbclid=1&id=7#reviews", "https://shop.com/item?id=7", "shop.com", true},
+		{"https://shop.com/search?q=b&a=1", "https://shop.com/search?a=1&q=b", "shop.com", true},
+		{"telegram.me/channel", "https://t.me/channel", "t.me", true},
+		{"ftp://files.example.com/x", "", "", false},
+		{"   ", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			url, domain, ok := normalizeURL(tt.raw)
+			if url != tt.url || domain != tt.domain || ok != tt.ok {
+				t.Errorf("normalizeURL(%q) = %q, %q, %v; want %q, %q, %v", tt.raw, url, domain, ok, tt.url, tt.domain, tt.ok)
+			}
+		})
+	}
+}
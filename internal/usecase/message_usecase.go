@@ -1,8 +1,6 @@
 package usecase
 
 import (
-	"fmt"
-	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,24 +18,106 @@ type MessageUsecase interface {
 	GetPersons(chat domain.Chat) (string, string)
 	TotalDaysTalked(chat domain.Chat) int
 	MessagesPerDay(chat domain.Chat) map[string]map[string]int
+	TimeSeries(chat domain.Chat, opts SeriesOptions) (domain.TimeSeries, error)
+	Anomalies(chat domain.Chat, opts AnomalyOptions) (domain.AnomalyReport, error)
+	Forecast(chat domain.Chat, opts ForecastOptions) (domain.Forecast, error)
+	Milestones(chat domain.Chat, opts MilestoneOptions) ([]domain.Milestone, error)
+	Wrapped(chat domain.Chat, opts WrappedOptions) (domain.Wrapped, error)
 	WeeklyStats(chat domain.Chat) map[string]map[string]int
 	HourlyStats(chat domain.Chat) map[string]map[string]int
+	Chronotypes(chat domain.Chat, opts ChronotypeOptions) (domain.ChronotypeReport, error)
+	ActivityHeatmap(chat domain.Chat, opts HeatmapOptions) (domain.Heatmap, error)
 	MostActiveDayOfWeek(chat domain.Chat) map[string]string
 	MessageLengthStatistics(chat domain.Chat) map[string]map[string]float64
 	ReplyTimeAnalysis(chat domain.Chat) map[string]float64
+	Bursts(chat domain.Chat, opts BurstOptions) (domain.BurstReport, error)
+	TurnTaking(chat domain.Chat, opts TurnOptions) (domain.TurnTakingReport, error)
+	Silences(chat domain.Chat, opts SilenceOptions) (domain.SilenceReport, error)
 	CountConversationStartersPerDay(chat domain.Chat) (map[string]int, error)
-	CountConsecutiveDays(chat domain.Chat) (map[string][]interface{}, error)
+	CountConsecutiveDays(chat domain.Chat, opts StreakOptions) (map[string]domain.Streak, error)
 	GetSharedInterests(chat domain.Chat) []string
+	DistinctiveVocabulary(chat domain.Chat, opts VocabularyOptions) (domain.VocabularyReport, error)
+	Phrases(chat domain.Chat, opts PhraseOptions) (domain.PhraseReport, error)
+	LexicalDiversity(chat domain.Chat, loc *time.Location) (domain.LexicalDiversityReport, error)
+	Topics(chat domain.Chat, opts TopicOptions) (domain.TopicReport, error)
+	Sentiment(chat domain.Chat, opts SentimentOptions) (domain.SentimentReport, error)
+	Questions(chat domain.Chat, opts QuestionOptions) (domain.QuestionReport, error)
+	Rituals(chat domain.Chat, opts RitualOptions) (domain.RitualReport, error)
+	Laughter(chat domain.Chat, opts LaughterOptions) (domain.LaughterReport, error)
+	Links(chat domain.Chat, opts LinkOptions) (domain.LinkReport, error)
+	Entities(chat domain.Chat, top int) (domain.EntityReport, error)
 	AverageMessagesPerDay(chat domain.Chat) map[string]float64
 	CountWord(chat domain.Chat) (map[string]int, map[string]int, error)
-	RelationshipScore(chat domain.Chat) (float64, error)
-	CurrentStreak(chat domain.Chat) (map[string][]interface{}, error)
+	TermTimeline(chat domain.Chat, queries []TermQuery, loc *time.Location) (domain.TermTimeline, error)
+	BuildSearchIndex(chat domain.Chat) domain.SearchIndex
+	Search(postings domain.SearchIndex, load MessageLoader, opts SearchOptions) (domain.SearchResult, error)
+	RelationshipScore(chat domain.Chat, weights domain.ScoreWeights) (domain.RelationshipScore, error)
+	ScoreWeights(profile string, overrides map[string]float64) (domain.ScoreWeights, error)
+	RelationshipScoreTrend(chat domain.Chat, weights domain.ScoreWeights, opts TrendOptions) ([]domain.ScorePoint, error)
+	CurrentStreak(chat domain.Chat, opts StreakOptions) (map[string]domain.Streak, error)
+	Streaks(chat domain.Chat, opts StreakOptions) (domain.StreakReport, error)
 }
 
-type messageUsecase struct{}
+// Config carries the optional server-side settings of the usecase. The zero
+// value uses the built-in defaults everywhere.
+type Config struct {
+	// ScoreProfiles holds named weight sets for RelationshipScore.
+	ScoreProfiles map[string]domain.ScoreWeights
+	// Lexicons adds sentiment lexicons, replacing built-in ones with the same language.
+	Lexicons []Lexicon
+	// QuestionRules adds question rules by language, replacing built-in ones with the same language.
+	QuestionRules map[string]domain.QuestionRules
+	// RitualPatterns adds greeting and farewell phrases by language, replacing built-in ones with the same language.
+	RitualPatterns map[string]domain.RitualPatterns
+	// MilestonePhrases adds phrases whose first use is a milestone, replacing built-in ones with the same name.
+	MilestonePhrases domain.MilestonePhrases
+}
+
+type messageUsecase struct {
+	scoreProfiles    map[string]domain.ScoreWeights
+	lexicons         map[string]Lexicon
+	questionRules    map[string]domain.QuestionRules
+	ritualPatterns   map[string]domain.RitualPatterns
+	milestonePhrases domain.MilestonePhrases
+}
+
+func NewMessageUsecase(config Config) MessageUsecase {
+	lexicons := builtinLexicons()
+	for _, lexicon := range config.Lexicons {
+		lexicons[lexicon.Language()] = lexicon
+	}
+
+	questionRules := make(map[string]domain.QuestionRules, len(builtinQuestionRules)+len(config.QuestionRules))
+	for language, rules := range builtinQuestionRules {
+		questionRules[language] = rules
+	}
+	for language, rules := range config.QuestionRules {
+		questionRules[language] = rules
+	}
 
-func NewMessageUsecase() MessageUsecase {
-	return &messageUsecase{}
+	ritualPatterns := make(map[string]domain.RitualPatterns, len(builtinRitualPatterns)+len(config.RitualPatterns))
+	for language, patterns := range builtinRitualPatterns {
+		ritualPatterns[language] = patterns
+	}
+	for language, patterns := range config.RitualPatterns {
+		ritualPatterns[language] = patterns
+	}
+
+	milestonePhrases := make(domain.MilestonePhrases, len(builtinMilestonePhrases)+len(config.MilestonePhrases))
+	for name, phrases := range builtinMilestonePhrases {
+		milestonePhrases[name] = phrases
+	}
+	for name, phrases := range config.MilestonePhrases {
+		milestonePhrases[name] = phrases
+	}
+
+	return &messageUsecase{
+		scoreProfiles:    config.ScoreProfiles,
+		lexicons:         lexicons,
+		questionRules:    questionRules,
+		ritualPatterns:   ritualPatterns,
+		milestonePhrases: milestonePhrases,
+	}
 }
 
 func (u *messageUsecase) GetPersons(chat domain.Chat) (string, string) {
@@ -51,6 +131,10 @@ func (u *messageUsecase) SeparateMessagesByPerson(chat domain.Chat) (map[string]
 	personTwoID := "user" + strconv.Itoa(chat.ID)
 	var personOne string
 	for _, message := range chat.Messages {
+		// Service entries such as calls have no sender.
+		if isServiceMessage(message) {
+			continue
+		}
 		sender := message.From
 		senderID := message.FromID
 		if senderID != personTwoID {
@@ -116,22 +200,13 @@ func (u *messageUsecase) CountWords(chat domain.Chat) (map[string]map[string]int
 
 	messagesByPerson, personOne, personTwo := u.SeparateMessagesByPerson(chat)
 
-	cleanWord := func(word string) string {
-		return strings.ToLower(strings.Trim(word, ".,!\""))
-	}
-
 	for person, messages := range messagesByPerson {
 		for _, msg := range messages {
 			text, ok := msg.Text.(string)
 			if !ok {
 				continue
 			}
-			words := strings.Fields(text)
-			for _, word := range words {
-				cleanedWord := cleanWord(word)
-				if cleanedWord == "" {
-					continue
-				}
+			for _, cleanedWord := range tokenize(text) {
 				combinedWordCount[cleanedWord]++
 				if person == personOne {
 					personOneWordCount[cleanedWord]++
@@ -173,8 +248,12 @@ func (u *messageUsecase) CountWord(chat domain.Chat) (map[string]int, map[string
 		}
 	}
 
-	average1 = wordCount[personOne] / len(messagesByPerson[personOne])
-	average2 = wordCount[personTwo] / len(messagesByPerson[personTwo])
+	if count := len(messagesByPerson[personOne]); count > 0 {
+		average1 = wordCount[personOne] / count
+	}
+	if count := len(messagesByPerson[personTwo]); count > 0 {
+		average2 = wordCount[personTwo] / count
+	}
 
 	averages := map[string]int{
 		personOne: average1,
@@ -529,132 +608,6 @@ func (u *messageUsecase) CountConversationStartersPerDay(chat domain.Chat) (map[
 	return conversationStarters, nil
 }
 
-func (u *messageUsecase) CountConsecutiveDays(chat domain.Chat) (map[string][]interface{}, error) {
-	messageByPerson, personOne, personTwo := u.SeparateMessagesByPerson(chat)
-	consecutiveDays := map[string][]interface{}{
-		personOne: {0, "", ""},
-		personTwo: {0, "", ""},
-		"overall": {0, "", ""},
-	}
-
-	messages := chat.Messages
-	sort.Slice(messages, func(i, j int) bool {
-		dateI, _ := time.Parse("2006-01-02T15:04:05", messages[i].Date)
-		dateJ, _ := time.Parse("2006-01-02T15:04:05", messages[j].Date)
-		return dateI.Before(dateJ)
-	})
-
-	prevDate := ""
-	days := make(map[string]bool)
-	start := 0
-	end := 0
-	startDate := ""
-	endDate := ""
-	number := 0
-
-	for _, message := range messages {
-		date := message.Date
-		realDate := strings.Split(date, "T")[0]
-		if _, exists := days[realDate]; !exists {
-			days[realDate] = true
-		} else {
-			continue
-		}
-
-		if prevDate == "" {
-			startDate = realDate
-		}
-
-		if prevDate != "" && isConsecutive(prevDate, date) {
-			end += 1
-			endDate = realDate
-		} else {
-
-			number = end - start + 1
-			if number > consecutiveDays["overall"][0].(int) {
-				consecutiveDays["overall"][0] = number
-				consecutiveDays["overall"][1] = startDate
-				consecutiveDays["overall"][2] = endDate
-			}
-			start = 0
-			end = 0
-			startDate = realDate
-		}
-		prevDate = date
-	}
-
-	number = end - start + 1
-	if number >= consecutiveDays["overall"][0].(int) {
-		consecutiveDays["overall"][0] = number
-		consecutiveDays["overall"][1] = startDate
-		consecutiveDays["overall"][2] = endDate
-	}
-
-	for person := range messageByPerson {
-		if _, exists := consecutiveDays[person]; !exists {
-			// Initialize the entry if it doesn't exist
-			consecutiveDays[person] = []interface{}{0, "", ""}
-		}
-
-		messages := messageByPerson[person]
-		sort.Slice(messages, func(i, j int) bool {
-			dateI, _ := time.Parse("2006-01-02T15:04:05", messages[i].Date)
-			dateJ, _ := time.Parse("2006-01-02T15:04:05", messages[j].Date)
-			return dateI.Before(dateJ)
-		})
-
-		prevDate := ""
-		days := make(map[string]bool)
-
-		start := 0
-		end := 0
-		startDate := ""
-		endDate := ""
-		number := 0
-
-		for _, message := range messages {
-			date := message.Date
-			realDate := strings.Split(date, "T")[0]
-
-			if _, exists := days[realDate]; !exists {
-				days[realDate] = true
-			} else {
-				continue
-			}
-
-			if prevDate == "" {
-				startDate = realDate
-			}
-
-			if prevDate != "" && isConsecutive(prevDate, date) {
-				end += 1
-				endDate = realDate
-			} else {
-				number = end - start + 1
-				if number > consecutiveDays[person][0].(int) {
-					consecutiveDays[person][0] = number
-					consecutiveDays[person][1] = startDate
-					consecutiveDays[person][2] = endDate
-				}
-
-				start = 0
-				end = 0
-				startDate = realDate
-			}
-			prevDate = date
-		}
-
-		number = end - start + 1
-		if number >= consecutiveDays[person][0].(int) {
-			consecutiveDays[person][0] = number
-			consecutiveDays[person][1] = startDate
-			consecutiveDays[person][2] = endDate
-		}
-	}
-
-	return consecutiveDays, nil
-}
-
 func findMax(arr []int) int {
 	if len(arr) == 0 {
 		return 0
@@ -669,90 +622,15 @@ func findMax(arr []int) int {
 	return max
 }
 
-func isConsecutive(date1, date2 string) bool {
-	layout := "2006-01-02"
-	t1, err1 := time.Parse(layout, strings.Split(date1, "T")[0])
-	t2, err2 := time.Parse(layout, strings.Split(date2, "T")[0])
-
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	return t2.Sub(t1).Hours() <= 24
-}
-
-func (u *messageUsecase) CurrentStreak(chat domain.Chat) (map[string][]interface{}, error) {
-	consecutiveDays := map[string][]interface{}{
-		"overall": {0, "", ""},
-	}
-
-	messages := chat.Messages
-	sort.Slice(messages, func(i, j int) bool {
-		dateI, _ := time.Parse("2006-01-02T15:04:05", messages[i].Date)
-		dateJ, _ := time.Parse("2006-01-02T15:04:05", messages[j].Date)
-		return dateI.Before(dateJ)
-	})
-
-	today := time.Now().Format("2006-01-02")
-	if len(messages) == 0 || strings.Split(messages[len(messages)-1].Date, "T")[0] != today {
-		return consecutiveDays, nil
-	}
-
-	prevDate := ""
-	days := make(map[string]bool)
-	start := 0
-	end := 0
-	startDate := ""
-	endDate := ""
-	number := 0
-	for i := len(messages) - 1; i >= 0; i-- {
-		message := messages[i]
-		date := message.Date
-		realDate := strings.Split(date, "T")[0]
-		if _, exists := days[realDate]; !exists {
-			days[realDate] = true
-		} else {
-			continue
-		}
-
-		if prevDate == "" {
-			startDate = realDate
-		}
-
-		if prevDate != "" && isConsecutive(prevDate, date) {
-			end += 1
-			endDate = realDate
-		} else {
-			break
-		}
-		prevDate = date
-	}
-
-	number = end - start + 1
-	if number > consecutiveDays["overall"][0].(int) {
-		consecutiveDays["overall"][0] = number
-		consecutiveDays["overall"][1] = startDate
-		consecutiveDays["overall"][2] = endDate
-	}
-	return consecutiveDays, nil
-}
-
 func countWords2(messages []domain.Message) map[string]int {
 	wordCount := make(map[string]int)
-	cleanWord := func(word string) string {
-		return strings.ToLower(strings.Trim(word, ".,!\""))
-	}
 
 	for _, msg := range messages {
 		text, ok := msg.Text.(string)
 		if !ok {
 			continue
 		}
-		words := strings.Fields(text)
-		for _, word := range words {
-			cleanedWord := cleanWord(word)
-			if cleanedWord == "" {
-				continue
-			}
+		for _, cleanedWord := range tokenize(text) {
 			wordCount[cleanedWord]++
 		}
 	}
@@ -824,106 +702,3 @@ func (u *messageUsecase) AverageMessagesPerDay(chat domain.Chat) map[string]floa
 
 	return averageMessagesPerDay
 }
-
-func (u *messageUsecase) RelationshipScore(chat domain.Chat) (float64, error) {
-	// Get basic details
-	personOne, personTwo := u.GetPersons(chat)
-
-	totalMessages, personOneMessages, personTwoMessages := u.CountMessages(chat)
-	per1 := float64(personOneMessages) * 100 / float64(totalMessages)
-	per2 := float64(personTwoMessages) * 100 / float64(totalMessages)
-	fmt.Println("per1", per1)
-	fmt.Println("per2", per2)
-	fmt.Println(" ")
-
-	s1 := 0.0
-	if per1 < per2 {
-		s1 = (1 - (per1 / per2)) * 15
-	} else {
-		s1 = (1 - (per2 / per1)) * 15
-	}
-
-	totalDaysTalked := u.TotalDaysTalked(chat)
-	consecutiveDays, err := u.CountConsecutiveDays(chat)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count consecutive days: %v", err)
-	}
-
-	personOneConsecutiveDays := consecutiveDays[personOne][0].(int)
-	personTwoConsecutiveDays := consecutiveDays[personTwo][0].(int)
-	overallConsecutiveDays := consecutiveDays["overall"][0].(int)
-
-	f1 := float64(personOneConsecutiveDays) / float64(overallConsecutiveDays)
-	f2 := float64(personTwoConsecutiveDays) / float64(overallConsecutiveDays)
-
-	fmt.Println("personOneConsecutiveDays", personOneConsecutiveDays)
-	fmt.Println("personTwoConsecutiveDays", personTwoConsecutiveDays)
-	fmt.Println("overall", overallConsecutiveDays)
-	fmt.Println(" ")
-	dif1 := math.Abs(f1-f2) * 10
-	dif2 := (math.Abs(1-f2) + math.Abs(1-f1)) * 5
-	s2 := dif1 + dif2
-
-	s3 := 0.0
-	if totalDaysTalked > 365 {
-		s3 = 0
-	} else {
-		s3 = (1 - (float64(overallConsecutiveDays) / float64(totalDaysTalked))) * 2
-	}
-
-	acvtiveDay := u.MostActiveDayOfWeek(chat)
-	personOneActiveDay := acvtiveDay[personOne]
-	personTwoAvtiveDay := acvtiveDay[personTwo]
-	fmt.Println("personOneActiveDays", personOneActiveDay)
-	fmt.Println("personTwoActiveDays", personTwoAvtiveDay)
-	fmt.Println(" ")
-
-	s4 := 0.0
-	if personOneActiveDay == personTwoAvtiveDay {
-		s4 = 1
-	}
-
-	replyTime := u.ReplyTimeAnalysis(chat)
-	averageReplyTime := replyTime["average"]
-	s5 := 0.25 * averageReplyTime
-	fmt.Println("averageReplyTime", averageReplyTime)
-
-	_, average, err := u.CountWord(chat)
-
-	if err != nil {
-		return 0, fmt.Errorf("failed to count words: %v", err)
-	}
-
-	personOneWordCount := average[personOne]
-	personTwoWordCount := average[personTwo]
-
-	fmt.Println("personOneWordCount", personOneWordCount)
-	fmt.Println("personTwoWordCount", personTwoWordCount)
-	fmt.Println(" ")
-
-	s6 := 0.0
-	if personOneWordCount < personTwoWordCount {
-		s6 = (1 - (float64(personOneWordCount) / float64(personTwoWordCount))) * 5
-	} else {
-		s6 = (1 - (float64(personTwoWordCount) / float64(personOneWordCount))) * 5
-	}
-
-	averageMessages := u.AverageMessagesPerDay(chat)
-	personOneAverageMessages := averageMessages[personOne]
-	personTwoAverageMessages := averageMessages[personTwo]
-	fmt.Println("personOneAverageMessages", personOneAverageMessages)
-	fmt.Println("personTwoAverageMessages", personTwoAverageMessages)
-	total := personOneAverageMessages + personTwoAverageMessages
-	p1 := personOneAverageMessages / total
-	p2 := personTwoAverageMessages / total
-	pd := math.Abs(p1 - p2)
-
-	fmt.Println(" ")
-	s7 := pd * 20
-
-	fmt.Println(s1, s2, s3, s4, s5, s6, s7)
-
-	relationshipScore := 100 - (s1 + s2 + s3 - s4 + s5 + s6 + s7)
-	return math.Round(relationshipScore), nil
-
-}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// Milestone kinds.
+const (
+	MilestoneFirstMessage        = "firstMessage"
+	MilestoneMessageCount        = "messageCount"
+	MilestonePhrase              = "phrase"
+	MilestoneAnniversary         = "anniversary"
+	MilestoneBusiestDay          = "busiestDay"
+	MilestoneLongestConversation = "longestConversation"
+)
+
+// MilestoneOptions controls Milestones.
+type MilestoneOptions struct {
+	// Location is the timezone used for dates. Nil keeps the export's wall clock.
+	Location *time.Location
+	// SessionGap splits conversations when finding the longest one.
+	SessionGap time.Duration
+}
+
+// milestoneCounts are the message numbers worth celebrating.
+var milestoneCounts = []int{1000, 10000, 100000, 1000000}
+
+var builtinMilestonePhrases = domain.MilestonePhrases{
+	"i love you": {
+		"i love you", "love you", "i love u", "love u", "ily",
+		"እወድሃለሁ", "እወድሻለሁ", "я тебя люблю", "люблю тебя",
+		"te quiero", "te amo", "je t'aime", "ich liebe dich",
+	},
+	"i miss you": {"i miss you", "miss you", "miss u", "ናፍቀኸኛል", "ናፍቀሽኛል", "скучаю по тебе", "te extraño"},
+}
+
+// Milestones lists the chat's notable moments in chronological order: the
+// first message, every round message count and who sent it, each
+// participant's first use of the configured phrases, the yearly
+// anniversaries of first contact including the next one, the busiest day
+// and the longest conversation.
+func (u *messageUsecase) Milestones(chat domain.Chat, opts MilestoneOptions) ([]domain.Milestone, error) {
+	messages := u.sortedMessages(chat, opts.Location)
+	if len(messages) == 0 {
+		return []domain.Milestone{}, nil
+	}
+
+	// Milestones are ordered by day, then moments of that day by time, then
+	// whole-day milestones, so the sort never depends on how Date is written.
+	type datedMilestone struct {
+		domain.Milestone
+		day      int
+		at       time.Time
+		wholeDay bool
+	}
+	var dated []datedMilestone
+	addMessage := func(milestone domain.Milestone, message timedMessage) {
+		dated = append(dated, datedMilestone{Milestone: milestone, day: dayNumber(message.At), at: message.At})
+	}
+	addDay := func(milestone domain.Milestone, day int) {
+		milestone.Date = dayToDate(day)
+		dated = append(dated, datedMilestone{Milestone: milestone, day: day, wholeDay: true})
+	}
+
+	first := messages[0]
+	addMessage(messageMilestone(MilestoneFirstMessage, "First message", first), first)
+	for _, count := range milestoneCounts {
+		if count > len(messages) {
+			break
+		}
+		milestone := messageMilestone(MilestoneMessageCount, fmt.Sprintf("Message #%d", count), messages[count-1])
+		milestone.Value = count
+		addMessage(milestone, messages[count-1])
+	}
+
+	names := make([]string, 0, len(u.milestonePhrases))
+	phrases := make(map[string][][]string, len(u.milestonePhrases))
+	for name, variants := range u.milestonePhrases {
+		names = append(names, name)
+		for _, variant := range variants {
+			if words := wordTokens(variant); len(words) > 0 {
+				phrases[name] = append(phrases[name], words)
+			}
+		}
+	}
+	sort.Strings(names)
+	said := make(map[string]bool)
+	dayCounts := make(map[int]int)
+	for _, message := range messages {
+		dayCounts[dayNumber(message.At)]++
+		words := wordTokens(messageText(message.Message))
+		for _, name := range names {
+			key := name + "\x00" + message.From
+			if said[key] || !u.containsAffirmedPhrase(words, phrases[name]) {
+				continue
+			}
+			said[key] = true
+			addMessage(messageMilestone(MilestonePhrase, fmt.Sprintf("First %q from %s", name, message.From), message), message)
+		}
+	}
+
+	last := messages[len(messages)-1]
+	for year := 1; ; year++ {
+		anniversary := anniversaryDay(first.At, year)
+		title := countNoun(year, "year") + " since the first message"
+		milestone := domain.Milestone{
+			Kind:  MilestoneAnniversary,
+			Title: title,
+			Value: year,
+		}
+		if anniversary > dayNumber(last.At) {
+			milestone.Upcoming = true
+			addDay(milestone, anniversary)
+			break
+		}
+		addDay(milestone, anniversary)
+	}
+
+	busiestDay, busiestCount := 0, 0
+	for day, count := range dayCounts {
+		if count > busiestCount || (count == busiestCount && day < busiestDay) {
+			busiestDay, busiestCount = day, count
+		}
+	}
+	addDay(domain.Milestone{
+		Kind:  MilestoneBusiestDay,
+		Title: "Busiest day",
+		Value: busiestCount,
+	}, busiestDay)
+
+	var longest session
+	for _, s := range splitSessions(messages, opts.SessionGap) {
+		if longest.Messages == nil || s.End().Sub(s.Start()) > longest.End().Sub(longest.Start()) {
+			longest = s
+		}
+	}
+	minutes := int(longest.End().Sub(longest.Start()).Round(time.Minute).Minutes())
+	title := fmt.Sprintf("Longest conversation (%s, %s)", conversationLength(minutes), countNoun(len(longest.Messages), "message"))
+	milestone := messageMilestone(MilestoneLongestConversation, title, longest.Messages[0])
+	milestone.Value = minutes
+	addMessage(milestone, longest.Messages[0])
+
+	sort.SliceStable(dated, func(i, j int) bool {
+		if dated[i].day != dated[j].day {
+			return dated[i].day < dated[j].day
+		}
+		if dated[i].wholeDay != dated[j].wholeDay {
+			return !dated[i].wholeDay
+		}
+		return dated[i].at.Before(dated[j].at)
+	})
+	milestones := make([]domain.Milestone, len(dated))
+	for i, d := range dated {
+		milestones[i] = d.Milestone
+	}
+	return milestones, nil
+}
+
+// conversationLength formats minutes for a title: "under a minute",
+// "1 minute", "45 minutes" or "16h33m".
+func conversationLength(minutes int) string {
+	switch {
+	case minutes == 0:
+		return "under a minute"
+	case minutes < 60:
+		return countNoun(minutes, "minute")
+	default:
+		return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+	}
+}
+
+// countNoun writes count with noun, adding an "s" unless count is 1.
+func countNoun(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// anniversaryDay is the day number of the given yearly anniversary of at. A
+// first message on February 29 is remembered on February 28 in common years.
+func anniversaryDay(at time.Time, years int) int {
+	year := at.Year() + years
+	day := at.Day()
+	if last := time.Date(year, at.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+		day = last
+	}
+	return dayNumber(time.Date(year, at.Month(), day, 0, 0, 0, 0, time.UTC))
+}
+
+// containsAffirmedPhrase reports whether words contain one of phrases that is
+// not directly preceded by a negator of any lexicon, so "i don't love you" is
+// not mistaken for "love you".
+func (u *messageUsecase) containsAffirmedPhrase(words []string, phrases [][]string) bool {
+	for _, phrase := range phrases {
+		for i := 0; i+len(phrase) <= len(words); i++ {
+			if !hasPhraseAt(words, phrase, i) {
+				continue
+			}
+			if i == 0 || !u.isNegator(words[i-1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasPhraseAt(words, phrase []string, i int) bool {
+	for j, word := range phrase {
+		if words[i+j] != word {
+			return false
+		}
+	}
+	return len(phrase) > 0
+}
+
+func (u *messageUsecase) isNegator(token string) bool {
+	token = strings.ReplaceAll(token, "’", "'")
+	for _, lexicon := range u.lexicons {
+		if lexicon.IsNegator(token) {
+			return true
+		}
+	}
+	return false
+}
+
+func messageMilestone(kind, title string, message timedMessage) domain.Milestone {
+	return domain.Milestone{
+		Kind:      kind,
+		Title:     title,
+		Date:      message.At.Format(dateLayout),
+		Time:      message.At.Format("15:04:05"),
+		From:      message.From,
+		MessageID: message.ID,
+	}
+}
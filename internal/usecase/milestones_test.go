@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestMilestones(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 2, 29, 9, 0), "Alice", "hi").
+		Add(date(2024, 2, 29, 9, 5), "Bob", "i don't love you").
+		Add(date(2024, 2, 29, 9, 10), "Alice", "I do not love u either").
+		Add(date(2024, 3, 1, 9, 0), "Bob", "ok I love you").
+		Add(date(2024, 3, 1, 9, 1), "Bob", "love you").
+		Add(date(2024, 3, 2, 20, 0), "Alice", "miss you")
+	for i := 0; i < 994; i++ {
+		chat.Add(date(2025, 3, 10, 10, 0).Add(time.Duration(i)*time.Minute), "Alice", "ok")
+	}
+
+	milestones, err := newTestUsecase(t).Milestones(chat.Chat, MilestoneOptions{SessionGap: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kind  string
+		title string
+		date  string
+		time  string
+		from  string
+		value int
+	}{
+		{MilestoneFirstMessage, "First message", "2024-02-29", "09:00:00", "Alice", 0},
+		{MilestonePhrase, `First "i love you" from Bob`, "2024-03-01", "09:00:00", "Bob", 0},
+		{MilestonePhrase, `First "i miss you" from Alice`, "2024-03-02", "20:00:00", "Alice", 0},
+		{MilestoneAnniversary, "1 year since the first message", "2025-02-28", "", "", 1},
+		{MilestoneLongestConversation, "Longest conversation (16h33m, 994 messages)", "2025-03-10", "10:00:00", "Alice", 993},
+		{MilestoneBusiestDay, "Busiest day", "2025-03-10", "", "", 840},
+		{MilestoneMessageCount, "Message #1000", "2025-03-11", "02:33:00", "Alice", 1000},
+		{MilestoneAnniversary, "2 years since the first message", "2026-02-28", "", "", 2},
+	}
+	if len(milestones) != len(tests) {
+		t.Fatalf("milestones = %+v; want %d", milestones, len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := milestones[i]
+			want := domain.Milestone{Kind: tt.kind, Title: tt.title, Date: tt.date, Time: tt.time, From: tt.from, Value: tt.value}
+			got.MessageID, got.Upcoming = 0, false
+			if got != want {
+				t.Errorf("milestone %d = %+v; want %+v", i, got, want)
+			}
+		})
+	}
+	if !milestones[len(milestones)-1].Upcoming {
+		t.Errorf("last anniversary should be upcoming")
+	}
+}
+
+func TestMilestonesSameDayOrder(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 5, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 5, 9, 1), "Bob", "hey")
+
+	milestones, err := newTestUsecase(t).Milestones(chat.Chat, MilestoneOptions{SessionGap: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, milestone := range milestones {
+		kinds = append(kinds, milestone.Kind)
+	}
+	want := []string{MilestoneFirstMessage, MilestoneLongestConversation, MilestoneBusiestDay, MilestoneAnniversary}
+	if len(kinds) != len(want) {
+		t.Fatalf("kinds = %v; want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("kinds = %v; want %v", kinds, want)
+		}
+	}
+}
+
+func TestAnniversaryDay(t *testing.T) {
+	tests := []struct {
+		at    time.Time
+		years int
+		want  string
+	}{
+		{date(2023, 6, 15, 23, 0), 1, "2024-06-15"},
+		{date(2024, 2, 29, 9, 0), 1, "2025-02-28"},
+		{date(2024, 2, 29, 9, 0), 4, "2028-02-29"},
+	}
+	for _, tt := range tests {
+		if got := dayToDate(anniversaryDay(tt.at, tt.years)); got != tt.want {
+			t.Errorf("anniversaryDay(%v, %d) = %s; want %s", tt.at, tt.years, got, tt.want)
+		}
+	}
+}
+
+func TestContainsAffirmedPhrase(t *testing.T) {
+	phrases := [][]string{{"i", "love", "you"}, {"love", "you"}}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"i love you", true},
+		{"love you too", true},
+		{"i don't love you", false},
+		{"I don’t love you", false},
+		{"not love you", false},
+		{"i don't know, i love you", true},
+		{"lovely you", false},
+	}
+	uc := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := uc.containsAffirmedPhrase(wordTokens(tt.text), phrases); got != tt.want {
+				t.Errorf("containsAffirmedPhrase = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMilestonesSingleMessageConversation(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 5, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 6, 9, 0), "Bob", "hey")
+
+	milestones, err := newTestUsecase(t).Milestones(chat.Chat, MilestoneOptions{SessionGap: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, milestone := range milestones {
+		if milestone.Kind == MilestoneLongestConversation {
+			if want := "Longest conversation (under a minute, 1 message)"; milestone.Title != want {
+				t.Errorf("title = %q; want %q", milestone.Title, want)
+			}
+			return
+		}
+	}
+	t.Errorf("milestones = %+v; want a longest conversation", milestones)
+}
+
+func TestConversationLength(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    string
+	}{
+		{0, "under a minute"},
+		{1, "1 minute"},
+		{45, "45 minutes"},
+		{60, "1h00m"},
+		{993, "16h33m"},
+	}
+	for _, tt := range tests {
+		if got := conversationLength(tt.minutes); got != tt.want {
+			t.Errorf("conversationLength(%d) = %q; want %q", tt.minutes, got, tt.want)
+		}
+	}
+}
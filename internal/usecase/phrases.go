@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// PhraseOptions controls Phrases.
+type PhraseOptions struct {
+	// Location is the timezone used to count distinct days. Nil keeps the export's wall clock.
+	Location *time.Location
+	// MinCount is how often a phrase must be used to be listed.
+	MinCount int
+	// MinDays is on how many different days a phrase must appear to count as a catchphrase.
+	MinDays int
+	// Top is how many phrases each list returns.
+	Top int
+}
+
+// phraseUsage tracks one phrase for one participant.
+type phraseUsage struct {
+	count int
+	days  map[int]bool
+	first time.Time
+	last  time.Time
+}
+
+// Phrases counts bigrams and trigrams per participant, scores them as
+// collocations and picks out catchphrases: phrases a participant introduced
+// and kept using on different days, or that the other person started
+// repeating back.
+func (u *messageUsecase) Phrases(chat domain.Chat, opts PhraseOptions) (domain.PhraseReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	unigrams := make(map[string]int)
+	totalUnigrams := 0
+	phraseTotals := map[int]map[string]int{2: {}, 3: {}}
+	orderTotals := map[int]int{}
+	usage := map[string]map[string]*phraseUsage{personOne: {}, personTwo: {}}
+
+	for _, message := range chat.Messages {
+		if isServiceMessage(message) || (message.From != personOne && message.From != personTwo) {
+			continue
+		}
+		sentAt, err := messageTime(message, opts.Location)
+		if err != nil {
+			continue
+		}
+		words := wordTokens(messageText(message))
+		for _, word := range words {
+			unigrams[word]++
+			totalUnigrams++
+		}
+		for n := 2; n <= 3; n++ {
+			for _, phrase := range ngrams(words, n) {
+				phraseTotals[n][phrase]++
+				orderTotals[n]++
+
+				use := usage[message.From][phrase]
+				if use == nil {
+					use = &phraseUsage{days: make(map[int]bool), first: sentAt, last: sentAt}
+					usage[message.From][phrase] = use
+				}
+				use.count++
+				use.days[dayNumber(sentAt)] = true
+				if sentAt.Before(use.first) {
+					use.first = sentAt
+				}
+				if sentAt.After(use.last) {
+					use.last = sentAt
+				}
+			}
+		}
+	}
+
+	// npmi scores how much more often the words of a phrase occur together
+	// than they would by chance. Dividing by (n-1)·-log p(phrase) puts
+	// phrases of every length on the same [-1, 1] scale.
+	npmi := func(phrase string, n int) float64 {
+		pPhrase := float64(phraseTotals[n][phrase]) / float64(orderTotals[n])
+		if pPhrase >= 1 {
+			return 1
+		}
+		logIndependent := 0.0
+		for _, word := range strings.Fields(phrase) {
+			logIndependent += math.Log(float64(unigrams[word]) / float64(totalUnigrams))
+		}
+		score := (math.Log(pPhrase) - logIndependent) / (float64(n-1) * -math.Log(pPhrase))
+		return math.Max(-1, math.Min(1, score))
+	}
+
+	report := domain.PhraseReport{
+		Bigrams:      make(map[string][]domain.PhraseStat),
+		Trigrams:     make(map[string][]domain.PhraseStat),
+		Catchphrases: []domain.Catchphrase{},
+	}
+	scores := map[int]map[string]float64{2: {}, 3: {}}
+	for n, phrases := range phraseTotals {
+		for phrase := range phrases {
+			scores[n][phrase] = roundTo(npmi(phrase, n), 4)
+		}
+	}
+
+	for _, person := range participants {
+		for n, target := range map[int]map[string][]domain.PhraseStat{2: report.Bigrams, 3: report.Trigrams} {
+			var stats []domain.PhraseStat
+			for phrase, use := range usage[person] {
+				if len(strings.Fields(phrase)) != n || use.count < opts.MinCount || scores[n][phrase] <= 0 {
+					continue
+				}
+				stats = append(stats, domain.PhraseStat{
+					Phrase: phrase,
+					Count:  use.count,
+					Score:  scores[n][phrase],
+					Counts: phraseCounts(usage, participants, phrase),
+				})
+			}
+			sort.Slice(stats, func(i, j int) bool {
+				if stats[i].Count != stats[j].Count {
+					return stats[i].Count > stats[j].Count
+				}
+				if stats[i].Score != stats[j].Score {
+					return stats[i].Score > stats[j].Score
+				}
+				return stats[i].Phrase < stats[j].Phrase
+			})
+			if len(stats) > opts.Top {
+				stats = stats[:opts.Top]
+			}
+			if stats == nil {
+				stats = []domain.PhraseStat{}
+			}
+			target[person] = stats
+		}
+	}
+
+	// Look at trigrams first so that "good night" is not reported again
+	// when it only ever appears as part of "good night babe".
+	var accepted []domain.Catchphrase
+	for _, n := range []int{3, 2} {
+		for phrase, total := range phraseTotals[n] {
+			if total < opts.MinCount || scores[n][phrase] <= 0 || coveredByLonger(phrase, total, accepted) {
+				continue
+			}
+			if catchphrase, ok := detectCatchphrase(phrase, usage, participants, opts); ok {
+				accepted = append(accepted, catchphrase)
+			}
+		}
+	}
+	sort.Slice(accepted, func(i, j int) bool {
+		ti, tj := catchphraseTotal(accepted[i]), catchphraseTotal(accepted[j])
+		if ti != tj {
+			return ti > tj
+		}
+		return accepted[i].Phrase < accepted[j].Phrase
+	})
+	if len(accepted) > opts.Top {
+		accepted = accepted[:opts.Top]
+	}
+	if accepted != nil {
+		report.Catchphrases = accepted
+	}
+	return report, nil
+}
+
+// detectCatchphrase decides whether phrase is a catchphrase. Its owner is
+// whoever used it first; it qualifies when the owner used it on at least
+// MinDays days, or when the other participant repeated it at least twice.
+func detectCatchphrase(phrase string, usage map[string]map[string]*phraseUsage, participants []string, opts PhraseOptions) (domain.Catchphrase, bool) {
+	var owner, other string
+	for i, person := range participants {
+		use := usage[person][phrase]
+		if use == nil {
+			continue
+		}
+		if owner == "" || use.first.Before(usage[owner][phrase].first) {
+			owner, other = person, participants[1-i]
+		}
+	}
+	if owner == "" {
+		return domain.Catchphrase{}, false
+	}
+
+	ownerUse := usage[owner][phrase]
+	otherUse := usage[other][phrase]
+	mirrored := otherUse != nil && otherUse.count >= 2
+	if len(ownerUse.days) < opts.MinDays && !mirrored {
+		return domain.Catchphrase{}, false
+	}
+
+	days := make(map[int]bool)
+	first, last := ownerUse.first, ownerUse.last
+	for _, person := range participants {
+		if use := usage[person][phrase]; use != nil {
+			for day := range use.days {
+				days[day] = true
+			}
+			if use.last.After(last) {
+				last = use.last
+			}
+		}
+	}
+
+	catchphrase := domain.Catchphrase{
+		Phrase:    phrase,
+		Owner:     owner,
+		Counts:    phraseCounts(usage, participants, phrase),
+		Days:      len(days),
+		FirstUsed: first.Format(dateLayout),
+		LastUsed:  last.Format(dateLayout),
+	}
+	if mirrored {
+		catchphrase.MirroredBy = other
+		catchphrase.FirstMirrored = otherUse.first.Format(dateLayout)
+	}
+	return catchphrase, true
+}
+
+// coveredByLonger reports whether phrase is almost always used as part of an
+// already accepted longer catchphrase.
+func coveredByLonger(phrase string, total int, accepted []domain.Catchphrase) bool {
+	for _, longer := range accepted {
+		if strings.Contains(" "+longer.Phrase+" ", " "+phrase+" ") && float64(total) <= 1.25*float64(catchphraseTotal(longer)) {
+			return true
+		}
+	}
+	return false
+}
+
+func catchphraseTotal(catchphrase domain.Catchphrase) int {
+	total := 0
+	for _, count := range catchphrase.Counts {
+		total += count
+	}
+	return total
+}
+
+func phraseCounts(usage map[string]map[string]*phraseUsage, participants []string, phrase string) map[string]int {
+	counts := make(map[string]int, len(participants))
+	for _, person := range participants {
+		counts[person] = 0
+		if use := usage[person][phrase]; use != nil {
+			counts[person] = use.count
+		}
+	}
+	return counts
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectCatchphrase(t *testing.T) {
+	participants := []string{"Alice", "Bob"}
+	use := func(count int, first time.Time, days ...int) *phraseUsage {
+		seen := make(map[int]bool)
+		for _, day := range days {
+			seen[day] = true
+		}
+		return &phraseUsage{count: count, days: seen, first: first, last: first}
+	}
+
+	tests := []struct {
+		name     string
+		alice    *phraseUsage
+		bob      *phraseUsage
+		ok       bool
+		owner    string
+		mirrored string
+	}{
+		{
+			name:  "kept on enough days",
+			alice: use(3, date(2024, 1, 1, 9, 0), 1, 2, 3),
+			ok:    true,
+			owner: "Alice",
+		},
+		{
+			name:  "too few days and nobody repeats it",
+			alice: use(2, date(2024, 1, 1, 9, 0), 1, 2),
+			bob:   use(1, date(2024, 1, 2, 9, 0), 2),
+			ok:    false,
+		},
+		{
+			name:     "repeated back twice",
+			alice:    use(2, date(2024, 1, 1, 9, 0), 1),
+			bob:      use(2, date(2024, 1, 2, 9, 0), 2),
+			ok:       true,
+			owner:    "Alice",
+			mirrored: "Bob",
+		},
+		{
+			name:     "owner is whoever said it first",
+			alice:    use(2, date(2024, 1, 5, 9, 0), 5),
+			bob:      use(3, date(2024, 1, 1, 9, 0), 1, 2, 3),
+			ok:       true,
+			owner:    "Bob",
+			mirrored: "Alice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := map[string]map[string]*phraseUsage{"Alice": {}, "Bob": {}}
+			if tt.alice != nil {
+				usage["Alice"]["see you"] = tt.alice
+			}
+			if tt.bob != nil {
+				usage["Bob"]["see you"] = tt.bob
+			}
+			catchphrase, ok := detectCatchphrase("see you", usage, participants, PhraseOptions{MinDays: 3})
+			if ok != tt.ok {
+				t.Fatalf("ok = %v; want %v", ok, tt.ok)
+			}
+			if ok && (catchphrase.Owner != tt.owner || catchphrase.MirroredBy != tt.mirrored) {
+				t.Errorf("owner = %q, mirrored by %q; want %q and %q", catchphrase.Owner, catchphrase.MirroredBy, tt.owner, tt.mirrored)
+			}
+		})
+	}
+}
+
+func TestPhrasesPrefersLongerCatchphrase(t *testing.T) {
+	fillers := []string{"coffee first", "train was late", "finished the report", "rain again", "new shoes"}
+	chat := newTestChat()
+	for day := 1; day <= 5; day++ {
+		from := "Alice"
+		if day > 3 {
+			from = "Bob"
+		}
+		chat.Add(date(2024, 1, day, 12, 0), from, fillers[day-1]).
+			Add(date(2024, 1, day, 23, 0), from, "good night babe")
+	}
+
+	report, err := newTestUsecase(t).Phrases(chat.Chat, PhraseOptions{MinCount: 2, MinDays: 3, Top: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Catchphrases) != 1 {
+		t.Fatalf("catchphrases = %+v; want only good night babe", report.Catchphrases)
+	}
+	got := report.Catchphrases[0]
+	if got.Phrase != "good night babe" || got.Owner != "Alice" || got.MirroredBy != "Bob" || got.Days != 5 {
+		t.Errorf("catchphrase = %+v; want good night babe by Alice, mirrored by Bob over 5 days", got)
+	}
+	if got.Counts["Alice"] != 3 || got.Counts["Bob"] != 2 {
+		t.Errorf("counts = %v; want Alice 3, Bob 2", got.Counts)
+	}
+
+	bigrams := report.Bigrams["Alice"]
+	if len(bigrams) != 2 || bigrams[0].Count != 3 || bigrams[0].Score <= 0 || bigrams[0].Score > 1 {
+		t.Errorf("Alice's bigrams = %+v; want good night and night babe with a positive NPMI", bigrams)
+	}
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// QuestionOptions controls Questions.
+type QuestionOptions struct {
+	// Location is the timezone used to split sessions. Nil keeps the export's wall clock.
+	Location *time.Location
+	// SessionGap is how long a silence ends the session a question can be answered in.
+	SessionGap time.Duration
+	// Languages picks which question rules to apply. Empty uses all of them.
+	Languages []string
+	// MaxUnanswered caps the list of unanswered questions.
+	MaxUnanswered int
+}
+
+var builtinQuestionRules = map[string]domain.QuestionRules{
+	"en": {
+		Marks:      []string{"?"},
+		StartWords: []string{"who", "what", "when", "where", "why", "how", "which", "whose", "wanna"},
+		AuxiliaryWords: []string{
+			"do", "does", "did", "is", "are", "was", "were", "can", "could",
+			"will", "would", "should", "shall", "have", "has", "may",
+		},
+		// "it" is left out: "do it now" is far more common than "is it".
+		Subjects: []string{
+			"i", "you", "u", "ya", "he", "she", "we", "they", "there", "this", "that",
+			"anyone", "anybody", "someone", "somebody", "everyone", "everybody",
+		},
+	},
+	"am": {
+		Marks:      []string{"?", "፧"},
+		StartWords: []string{"ምን", "ማን", "የት", "መቼ", "እንዴት", "ለምን", "ስንት", "የትኛው", "ምንድን", "ምንድነው"},
+	},
+	"ru": {
+		Marks:      []string{"?"},
+		StartWords: []string{"кто", "что", "где", "когда", "почему", "зачем", "как", "сколько", "какой", "какая", "какие", "чей", "куда", "откуда"},
+	},
+	"ar": {
+		Marks:      []string{"؟"},
+		StartWords: []string{"ماذا", "متى", "أين", "لماذا", "كيف", "كم", "هل"},
+	},
+}
+
+// questionClassifier marks messages as questions using the marks and start
+// words of several languages at once. Auxiliaries and subjects are kept per
+// language so one language's subject cannot complete another's auxiliary.
+type questionClassifier struct {
+	marks       []string
+	startWords  map[string]bool
+	auxiliaries []auxiliaryRule
+}
+
+// auxiliaryRule holds one language's auxiliary words and the subjects that
+// have to follow them.
+type auxiliaryRule struct {
+	words    map[string]bool
+	subjects map[string]bool
+}
+
+// Questions classifies messages as questions and reports, for each
+// participant, how many they asked, how many the other person answered
+// within the same session and how long that took on average.
+func (u *messageUsecase) Questions(chat domain.Chat, opts QuestionOptions) (domain.QuestionReport, error) {
+	languages, classifier, err := u.questionClassifier(opts.Languages)
+	if err != nil {
+		return domain.QuestionReport{}, err
+	}
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+
+	report := domain.QuestionReport{
+		Languages:  languages,
+		People:     make(map[string]domain.QuestionStats),
+		Unanswered: []domain.Question{},
+	}
+	messages := map[string]int{}
+	answerSeconds := map[string]float64{}
+	people := map[string]domain.QuestionStats{personOne: {}, personTwo: {}}
+
+	for _, s := range splitSessions(u.sortedMessages(chat, opts.Location), opts.SessionGap) {
+		for i, message := range s.Messages {
+			messages[message.From]++
+			if !classifier.isQuestion(messageText(message.Message)) {
+				continue
+			}
+			stats := people[message.From]
+			stats.Questions++
+
+			answered := false
+			for _, reply := range s.Messages[i+1:] {
+				if reply.From != message.From {
+					answered = true
+					answerSeconds[message.From] += reply.At.Sub(message.At).Seconds()
+					break
+				}
+			}
+			if answered {
+				stats.Answered++
+			} else {
+				report.Unanswered = append(report.Unanswered, domain.Question{
+					ID:   message.ID,
+					From: message.From,
+					Date: message.At.Format(dateTimeLayout),
+					Text: messageText(message.Message),
+				})
+			}
+			people[message.From] = stats
+		}
+	}
+
+	for person, stats := range people {
+		if messages[person] > 0 {
+			stats.QuestionShare = roundTo(float64(stats.Questions)/float64(messages[person]), 4)
+		}
+		if stats.Questions > 0 {
+			stats.AnswerRate = roundTo(float64(stats.Answered)/float64(stats.Questions), 4)
+		}
+		if stats.Answered > 0 {
+			stats.AverageAnswerSeconds = roundTo(answerSeconds[person]/float64(stats.Answered), 2)
+		}
+		report.People[person] = stats
+	}
+
+	for i, j := 0, len(report.Unanswered)-1; i < j; i, j = i+1, j-1 {
+		report.Unanswered[i], report.Unanswered[j] = report.Unanswered[j], report.Unanswered[i]
+	}
+	if len(report.Unanswered) > opts.MaxUnanswered {
+		report.Unanswered = report.Unanswered[:opts.MaxUnanswered]
+	}
+	return report, nil
+}
+
+// questionClassifier combines the rules of the requested languages, or of
+// every known language when none is requested.
+func (u *messageUsecase) questionClassifier(languages []string) ([]string, questionClassifier, error) {
+	if len(languages) == 0 {
+		for language := range u.questionRules {
+			languages = append(languages, language)
+		}
+		sort.Strings(languages)
+	}
+
+	classifier := questionClassifier{startWords: make(map[string]bool)}
+	seenMarks := make(map[string]bool)
+	for _, language := range languages {
+		rules, ok := u.questionRules[language]
+		if !ok {
+			return nil, classifier, fmt.Errorf("no question rules for language %q", language)
+		}
+		for _, mark := range rules.Marks {
+			if mark != "" && !seenMarks[mark] {
+				seenMarks[mark] = true
+				classifier.marks = append(classifier.marks, mark)
+			}
+		}
+		for _, word := range rules.StartWords {
+			classifier.startWords[strings.ToLower(word)] = true
+		}
+		if len(rules.AuxiliaryWords) > 0 && len(rules.Subjects) > 0 {
+			rule := auxiliaryRule{words: make(map[string]bool), subjects: make(map[string]bool)}
+			for _, word := range rules.AuxiliaryWords {
+				rule.words[strings.ToLower(word)] = true
+			}
+			for _, subject := range rules.Subjects {
+				rule.subjects[strings.ToLower(subject)] = true
+			}
+			classifier.auxiliaries = append(classifier.auxiliaries, rule)
+		}
+	}
+	return languages, classifier, nil
+}
+
+func (c questionClassifier) isQuestion(text string) bool {
+	for _, field := range strings.Fields(text) {
+		// A "?" inside a link starts its query string, not a question.
+		if strings.Contains(field, "://") {
+			continue
+		}
+		for _, mark := range c.marks {
+			if strings.Contains(field, mark) {
+				return true
+			}
+		}
+	}
+	words := wordTokens(text)
+	if len(words) < 2 {
+		return false
+	}
+	if c.startWords[words[0]] {
+		return true
+	}
+	for _, rule := range c.auxiliaries {
+		if rule.words[words[0]] && rule.subjects[words[1]] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestIsQuestion(t *testing.T) {
+	_, classifier, err := newTestUsecase(t).questionClassifier(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"are you coming?", true},
+		{"ok?", true},
+		{"do you want pizza", true},
+		{"Can someone check", true},
+		{"where are you", true},
+		{"wanna go out", true},
+		{"do it now", false},
+		{"is fine", false},
+		{"can't wait", false},
+		{"have fun", false},
+		{"where", false},
+		{"see https://example.com/search?q=go", false},
+		{"ምን ሆንክ", true},
+		{"как дела", true},
+		{"هل انت بخير", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := classifier.isQuestion(tt.text); got != tt.want {
+				t.Errorf("isQuestion(%q) = %v; want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestions(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "do you want coffee").
+		Add(date(2024, 1, 1, 9, 2), "Bob", "yes please").
+		Add(date(2024, 1, 1, 9, 3), "Bob", "do it now").
+		Add(date(2024, 1, 1, 9, 4), "Bob", "where is the sugar?").
+		Add(date(2024, 1, 1, 9, 5), "Bob", "never mind")
+
+	report, err := newTestUsecase(t).Questions(chat.Chat, QuestionOptions{SessionGap: time.Hour, Languages: []string{"en"}, MaxUnanswered: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		person string
+		want   domain.QuestionStats
+	}{
+		{"Alice", domain.QuestionStats{Questions: 1, QuestionShare: 1, Answered: 1, AnswerRate: 1, AverageAnswerSeconds: 120}},
+		{"Bob", domain.QuestionStats{Questions: 1, QuestionShare: 0.25}},
+	}
+	for _, tt := range tests {
+		if got := report.People[tt.person]; got != tt.want {
+			t.Errorf("%s = %+v; want %+v", tt.person, got, tt.want)
+		}
+	}
+	if len(report.Unanswered) != 1 || report.Unanswered[0].Text != "where is the sugar?" {
+		t.Errorf("unanswered = %+v; want Bob's sugar question", report.Unanswered)
+	}
+}
+
+func TestQuestionsRejectsUnknownLanguage(t *testing.T) {
+	_, err := newTestUsecase(t).Questions(newTestChat().Chat, QuestionOptions{Languages: []string{"xx"}})
+	if err == nil {
+		t.Fatal("expected an error for an unknown language")
+	}
+}
+
+func TestQuestionsKeepAuxiliariesPerLanguage(t *testing.T) {
+	// The rules are combined, but a subject of one language must not
+	// complete an auxiliary of the other.
+	u := NewMessageUsecase(Config{QuestionRules: map[string]domain.QuestionRules{
+		"xx": {Marks: []string{"?"}, AuxiliaryWords: []string{"ist"}, Subjects: []string{"es"}},
+	}}).(*messageUsecase)
+	_, classifier, err := u.questionClassifier([]string{"en", "xx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"ist es gut", true},
+		{"do you know", true},
+		{"do es it", false},
+		{"ist you here", false},
+	}
+	for _, tt := range tests {
+		if got := classifier.isQuestion(tt.text); got != tt.want {
+			t.Errorf("isQuestion(%q) = %v; want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestQuestionsUnansweredDateInLocation(t *testing.T) {
+	chat := newTestChat().Add(date(2024, 1, 1, 22, 30), "Alice", "are you up?")
+	tokyo := time.FixedZone("JST", 9*60*60)
+	chat.Messages[0].DateUnixtime = strconv.FormatInt(date(2024, 1, 1, 22, 30).Unix(), 10)
+
+	report, err := newTestUsecase(t).Questions(chat.Chat, QuestionOptions{Location: tokyo, SessionGap: time.Hour, MaxUnanswered: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Unanswered) != 1 || report.Unanswered[0].Date != "2024-01-02T07:30:00" {
+		t.Errorf("unanswered = %+v; want the question at 07:30 Tokyo time", report.Unanswered)
+	}
+}
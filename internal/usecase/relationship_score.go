@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"telegram-chat-analyzer/internal/domain"
+)
+
+// Relationship score components. Each one is also the key used to override
+// its weight in a score profile or request.
+const (
+	ComponentMessageBalance      = "messageBalance"
+	ComponentStreakBalance       = "streakBalance"
+	ComponentStreakParticipation = "streakParticipation"
+	ComponentStreakCoverage      = "streakCoverage"
+	ComponentSharedActiveDay     = "sharedActiveDay"
+	ComponentReplyTime           = "replyTime"
+	ComponentWordBalance         = "wordBalance"
+	ComponentDailyBalance        = "dailyBalance"
+)
+
+// DefaultScoreWeights returns the weights the score has always used.
+func DefaultScoreWeights() domain.ScoreWeights {
+	return domain.ScoreWeights{
+		ComponentMessageBalance:      15,
+		ComponentStreakBalance:       10,
+		ComponentStreakParticipation: 5,
+		ComponentStreakCoverage:      2,
+		ComponentSharedActiveDay:     1,
+		ComponentReplyTime:           0.25,
+		ComponentWordBalance:         5,
+		ComponentDailyBalance:        20,
+	}
+}
+
+// ScoreWeights resolves the weights for a request: built-in defaults, then
+// the named server profile (if any), then per-request overrides.
+func (u *messageUsecase) ScoreWeights(profile string, overrides map[string]float64) (domain.ScoreWeights, error) {
+	weights := DefaultScoreWeights()
+
+	if profile != "" {
+		profileWeights, exists := u.scoreProfiles[profile]
+		if !exists {
+			return nil, fmt.Errorf("unknown score profile %q", profile)
+		}
+		if err := mergeScoreWeights(weights, profileWeights); err != nil {
+			return nil, fmt.Errorf("score profile %q: %v", profile, err)
+		}
+	}
+
+	if err := mergeScoreWeights(weights, overrides); err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+func mergeScoreWeights(weights domain.ScoreWeights, overrides map[string]float64) error {
+	for name, weight := range overrides {
+		if _, known := weights[name]; !known {
+			return fmt.Errorf("unknown score component %q", name)
+		}
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("weight for %q must be a non-negative number", name)
+		}
+		weights[name] = weight
+	}
+	return nil
+}
+
+func (u *messageUsecase) RelationshipScore(chat domain.Chat, weights domain.ScoreWeights) (domain.RelationshipScore, error) {
+	personOne, personTwo := u.GetPersons(chat)
+	var components []domain.ScoreComponent
+
+	// penalty and bonus record a component; input is expected to already be
+	// on the scale the weight was tuned for. The score sums the unrounded
+	// contributions so rounding the reported ones cannot shift it.
+	score := 100.0
+	penalty := func(name string, input float64) {
+		score -= input * weights[name]
+		components = append(components, scoreComponent(name, input, weights[name], -1))
+	}
+	bonus := func(name string, input float64) {
+		score += input * weights[name]
+		components = append(components, scoreComponent(name, input, weights[name], 1))
+	}
+
+	totalMessages, personOneMessages, personTwoMessages := u.CountMessages(chat)
+	if totalMessages == 0 {
+		return domain.RelationshipScore{}, fmt.Errorf("no messages from either participant")
+	}
+	penalty(ComponentMessageBalance, imbalance(float64(personOneMessages), float64(personTwoMessages)))
+
+	consecutiveDays, err := u.CountConsecutiveDays(chat, StreakOptions{})
+	if err != nil {
+		return domain.RelationshipScore{}, fmt.Errorf("failed to count consecutive days: %v", err)
+	}
+	overallConsecutiveDays := consecutiveDays["overall"].Length
+	f1, f2 := 0.0, 0.0
+	if overallConsecutiveDays > 0 {
+		f1 = float64(consecutiveDays[personOne].Length) / float64(overallConsecutiveDays)
+		f2 = float64(consecutiveDays[personTwo].Length) / float64(overallConsecutiveDays)
+	}
+	penalty(ComponentStreakBalance, math.Abs(f1-f2))
+	penalty(ComponentStreakParticipation, math.Abs(1-f1)+math.Abs(1-f2))
+
+	// Long chats are not penalised for gaps in their longest streak.
+	totalDaysTalked := u.TotalDaysTalked(chat)
+	coverageGap := 0.0
+	if totalDaysTalked <= 365 {
+		coverageGap = 1 - float64(overallConsecutiveDays)/float64(totalDaysTalked)
+	}
+	penalty(ComponentStreakCoverage, coverageGap)
+
+	activeDay := u.MostActiveDayOfWeek(chat)
+	sameActiveDay := 0.0
+	if activeDay[personOne] == activeDay[personTwo] {
+		sameActiveDay = 1
+	}
+	bonus(ComponentSharedActiveDay, sameActiveDay)
+
+	replyTime := u.ReplyTimeAnalysis(chat)
+	penalty(ComponentReplyTime, replyTime["average"])
+
+	_, averageWords, err := u.CountWord(chat)
+	if err != nil {
+		return domain.RelationshipScore{}, fmt.Errorf("failed to count words: %v", err)
+	}
+	penalty(ComponentWordBalance, imbalance(float64(averageWords[personOne]), float64(averageWords[personTwo])))
+
+	averageMessages := u.AverageMessagesPerDay(chat)
+	dailyTotal := averageMessages[personOne] + averageMessages[personTwo]
+	dailyGap := 0.0
+	if dailyTotal > 0 {
+		dailyGap = math.Abs(averageMessages[personOne]-averageMessages[personTwo]) / dailyTotal
+	}
+	penalty(ComponentDailyBalance, dailyGap)
+
+	score = math.Max(0, math.Min(100, score))
+
+	return domain.RelationshipScore{
+		Score:      math.Round(score),
+		Components: components,
+	}, nil
+}
+
+func scoreComponent(name string, input, weight, sign float64) domain.ScoreComponent {
+	contribution := roundTo(sign*input*weight, 2)
+	if contribution == 0 {
+		contribution = 0 // normalise -0 so it is not serialised as "-0"
+	}
+	return domain.ScoreComponent{
+		Name:         name,
+		Input:        roundTo(input, 4),
+		Weight:       weight,
+		Contribution: contribution,
+	}
+}
+
+// imbalance is 0 when a and b are equal and approaches 1 as one side dominates.
+func imbalance(a, b float64) float64 {
+	low, high := math.Min(a, b), math.Max(a, b)
+	if high == 0 {
+		return 0
+	}
+	return 1 - low/high
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestScoreWeights(t *testing.T) {
+	u := NewMessageUsecase(Config{ScoreProfiles: map[string]domain.ScoreWeights{
+		"patient": {ComponentReplyTime: 0, ComponentDailyBalance: 10},
+		"broken":  {"typingSpeed": 1},
+	}}).(*messageUsecase)
+
+	tests := []struct {
+		name      string
+		profile   string
+		overrides map[string]float64
+		want      map[string]float64
+		wantErr   bool
+	}{
+		{"defaults", "", nil, map[string]float64{ComponentReplyTime: 0.25, ComponentDailyBalance: 20}, false},
+		{"profile", "patient", nil, map[string]float64{ComponentReplyTime: 0, ComponentDailyBalance: 10, ComponentMessageBalance: 15}, false},
+		{"override after profile", "patient", map[string]float64{ComponentDailyBalance: 3}, map[string]float64{ComponentReplyTime: 0, ComponentDailyBalance: 3}, false},
+		{"unknown profile", "strict", nil, nil, true},
+		{"profile with unknown component", "broken", nil, nil, true},
+		{"unknown component", "", map[string]float64{"typingSpeed": 1}, nil, true},
+		{"negative weight", "", map[string]float64{ComponentReplyTime: -1}, nil, true},
+		{"NaN weight", "", map[string]float64{ComponentReplyTime: math.NaN()}, nil, true},
+		{"infinite weight", "", map[string]float64{ComponentReplyTime: math.Inf(1)}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights, err := u.ScoreWeights(tt.profile, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v; want error %v", err, tt.wantErr)
+			}
+			if len(weights) != 0 && len(weights) != len(DefaultScoreWeights()) {
+				t.Errorf("weights = %v; want every component", weights)
+			}
+			for name, want := range tt.want {
+				if weights[name] != want {
+					t.Errorf("%s = %v; want %v", name, weights[name], want)
+				}
+			}
+		})
+	}
+}
+
+// scoreChat has Alice send 6 messages and Bob 4 over five days, with a
+// four-day streak from January 1 and Bob answering within two minutes.
+func scoreChat() *testChat {
+	chat := newTestChat()
+	for day := 1; day <= 4; day++ {
+		chat.Add(date(2024, 1, day, 10, 0), "Alice", "good morning you").
+			Add(date(2024, 1, day, 10, 2), "Bob", "morning to you")
+	}
+	return chat.Add(date(2024, 1, 4, 10, 5), "Alice", "hi").
+		Add(date(2024, 1, 6, 10, 5), "Alice", "hi again")
+}
+
+func TestRelationshipScoreComponents(t *testing.T) {
+	u := newTestUsecase(t)
+	score, err := u.RelationshipScore(scoreChat().Chat, DefaultScoreWeights())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.ScoreComponent{
+		{Name: ComponentMessageBalance, Input: 0.3333, Weight: 15, Contribution: -5},
+		{Name: ComponentStreakBalance, Input: 0, Weight: 10, Contribution: 0},
+		{Name: ComponentStreakParticipation, Input: 0, Weight: 5, Contribution: 0},
+		{Name: ComponentStreakCoverage, Input: 0.2, Weight: 2, Contribution: -0.4},
+		{Name: ComponentSharedActiveDay, Input: 0, Weight: 1, Contribution: 0},
+		{Name: ComponentReplyTime, Input: 2.2, Weight: 0.25, Contribution: -0.55},
+		{Name: ComponentWordBalance, Input: 0.3333, Weight: 5, Contribution: -1.67},
+		{Name: ComponentDailyBalance, Input: 0.2, Weight: 20, Contribution: -4},
+	}
+	if !reflect.DeepEqual(score.Components, want) {
+		t.Errorf("components = %+v; want %+v", score.Components, want)
+	}
+	// 100 - 5 - 0.4 - 0.55 - 1.67 - 4 = 88.38
+	if score.Score != 88 {
+		t.Errorf("score = %v; want 88", score.Score)
+	}
+}
+
+func TestRelationshipScoreSumsUnroundedContributions(t *testing.T) {
+	// Only the daily balance counts: 0.2 * 2.52 = 0.504 is reported as 0.5,
+	// but the score must use the exact value, 99.496, which rounds to 99.
+	weights := make(domain.ScoreWeights)
+	for name := range DefaultScoreWeights() {
+		weights[name] = 0
+	}
+	weights[ComponentDailyBalance] = 2.52
+
+	score, err := newTestUsecase(t).RelationshipScore(scoreChat().Chat, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := score.Components[len(score.Components)-1]; got.Name != ComponentDailyBalance || got.Contribution != -0.5 {
+		t.Errorf("daily balance = %+v; want a reported contribution of -0.5", got)
+	}
+	if score.Score != 99 {
+		t.Errorf("score = %v; want 99", score.Score)
+	}
+}
+
+func TestRelationshipScoreClamps(t *testing.T) {
+	// Both only ever write on Monday 2024-01-01, so they share their most
+	// active day and nothing else is unbalanced.
+	balanced := newTestChat().
+		Add(date(2024, 1, 1, 10, 0), "Alice", "hi there").
+		Add(date(2024, 1, 1, 10, 1), "Bob", "hi there")
+
+	tests := []struct {
+		name   string
+		chat   *testChat
+		weight string
+		want   float64
+	}{
+		{"bonus above 100", balanced, ComponentSharedActiveDay, 100},
+		{"penalty below 0", scoreChat(), ComponentMessageBalance, 0},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights, err := u.ScoreWeights("", map[string]float64{tt.weight: 1000})
+			if err != nil {
+				t.Fatal(err)
+			}
+			score, err := u.RelationshipScore(tt.chat.Chat, weights)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, component := range score.Components {
+				if component.Name == tt.weight && math.Abs(component.Contribution) < 100 {
+					t.Fatalf("%s = %+v; want it to push the score out of range", tt.weight, component)
+				}
+			}
+			if score.Score != tt.want {
+				t.Errorf("score = %v; want %v", score.Score, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelationshipScoreNeedsMessages(t *testing.T) {
+	if _, err := newTestUsecase(t).RelationshipScore(newTestChat().Chat, DefaultScoreWeights()); err == nil {
+		t.Error("expected an error for an empty chat")
+	}
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// RitualOptions controls Rituals.
+type RitualOptions struct {
+	// Location is the timezone used for days and times of day. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Languages picks which pattern sets to use. Empty uses all of them.
+	Languages []string
+	// DayStartHour is the hour a ritual day begins, so that a good night sent
+	// after midnight still belongs to the evening before.
+	DayStartHour int
+	// AsOf and GraceDays decide the current ritual streaks as in StreakOptions.
+	AsOf      time.Time
+	GraceDays int
+}
+
+var builtinRitualPatterns = map[string]domain.RitualPatterns{
+	"en": {
+		"morning": {"good morning", "gm", "goodmorning", "morning babe", "morning love", "rise and shine"},
+		"night":   {"good night", "goodnight", "gn", "night night", "nighty night", "sweet dreams", "nite"},
+	},
+	"am": {
+		"morning": {"እንደምን አደርክ", "እንደምን አደርሽ", "እንደምን አደራችሁ", "ደህና አደርክ", "ደህና አደርሽ", "እንዴት አደርክ", "እንዴት አደርሽ"},
+		"night":   {"ደህና እደር", "ደህና እደሪ", "ደህና እደሩ", "መልካም ሌሊት"},
+	},
+	"ru": {
+		"morning": {"доброе утро", "с добрым утром"},
+		"night":   {"спокойной ночи", "доброй ночи", "сладких снов"},
+	},
+	"es": {
+		"morning": {"buenos días", "buenos dias", "buen día", "buen dia"},
+		"night":   {"buenas noches", "dulces sueños"},
+	},
+	"ar": {
+		"morning": {"صباح الخير", "صباح النور"},
+		"night":   {"تصبح على خير", "تصبحين على خير", "ليلة سعيدة"},
+	},
+}
+
+// Rituals finds greeting and farewell rituals such as good morning and good
+// night, and reports per ritual who says it, who says it first each day, how
+// consistently, at what time of day, and the streaks of days it was kept.
+func (u *messageUsecase) Rituals(chat domain.Chat, opts RitualOptions) (domain.RitualReport, error) {
+	languages, patterns, err := u.selectRitualPatterns(opts.Languages)
+	if err != nil {
+		return domain.RitualReport{}, err
+	}
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+	dayStart := time.Duration(opts.DayStartHour) * time.Hour
+
+	type ritualUse struct {
+		messages map[string]int
+		// first is the earliest ritual message per person and ritual day.
+		first map[string]map[int]time.Time
+	}
+	uses := make(map[string]*ritualUse, len(patterns))
+	for name := range patterns {
+		uses[name] = &ritualUse{
+			messages: make(map[string]int),
+			first:    map[string]map[int]time.Time{personOne: {}, personTwo: {}},
+		}
+	}
+	activeDays := map[string]map[int]bool{personOne: {}, personTwo: {}}
+
+	for _, message := range u.sortedMessages(chat, opts.Location) {
+		day := dayNumber(message.At.Add(-dayStart))
+		activeDays[message.From][day] = true
+		words := wordTokens(messageText(message.Message))
+		for name, phrases := range patterns {
+			if !containsAnyPhrase(words, phrases) {
+				continue
+			}
+			use := uses[name]
+			use.messages[message.From]++
+			if _, seen := use.first[message.From][day]; !seen {
+				use.first[message.From][day] = message.At
+			}
+		}
+	}
+
+	report := domain.RitualReport{Languages: languages, Rituals: []domain.Ritual{}}
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	asOfDay := asOfDayNumber(StreakOptions{Location: opts.Location, AsOf: opts.AsOf})
+
+	for _, name := range names {
+		use := uses[name]
+		ritual := domain.Ritual{
+			Name:    name,
+			People:  make(map[string]domain.RitualStats, len(participants)),
+			Longest: make(map[string]domain.Streak),
+			Current: make(map[string]domain.Streak),
+		}
+
+		allDays := make(map[int]bool)
+		firstDays := make(map[string]int)
+		for day, at := range use.first[personOne] {
+			allDays[day] = true
+			other, both := use.first[personTwo][day]
+			if both {
+				ritual.MutualDays++
+			}
+			if !both || at.Before(other) {
+				firstDays[personOne]++
+			}
+		}
+		for day, at := range use.first[personTwo] {
+			allDays[day] = true
+			other, both := use.first[personOne][day]
+			if !both || at.Before(other) {
+				firstDays[personTwo]++
+			}
+		}
+		ritual.Days = len(allDays)
+
+		daysByKey := map[string][]int{"overall": sortedDays(allDays)}
+		for _, person := range participants {
+			days := make(map[int]bool, len(use.first[person]))
+			minutes := make([]int, 0, len(use.first[person]))
+			for day, at := range use.first[person] {
+				days[day] = true
+				minutes = append(minutes, minuteOfDay(at))
+			}
+			daysByKey[person] = sortedDays(days)
+
+			stats := domain.RitualStats{
+				Messages:  use.messages[person],
+				Days:      len(days),
+				FirstDays: firstDays[person],
+			}
+			if len(activeDays[person]) > 0 {
+				stats.Consistency = roundTo(float64(len(days))/float64(len(activeDays[person])), 4)
+			}
+			if len(minutes) > 0 {
+				stats.TypicalTime = formatMinuteOfDay(typicalMinuteOfDay(minutes))
+			}
+			ritual.People[person] = stats
+		}
+
+		for key, days := range daysByKey {
+			ritual.Longest[key] = longestStreak(findStreaks(days))
+			ritual.Current[key] = currentStreak(days, asOfDay, opts.GraceDays)
+		}
+		report.Rituals = append(report.Rituals, ritual)
+	}
+	return report, nil
+}
+
+// selectRitualPatterns merges the phrases of the requested languages, or of
+// every known language when none is requested, into one set per ritual.
+func (u *messageUsecase) selectRitualPatterns(languages []string) ([]string, map[string][][]string, error) {
+	if len(languages) == 0 {
+		for language := range u.ritualPatterns {
+			languages = append(languages, language)
+		}
+		sort.Strings(languages)
+	}
+
+	patterns := make(map[string][][]string)
+	for _, language := range languages {
+		set, ok := u.ritualPatterns[language]
+		if !ok {
+			return nil, nil, fmt.Errorf("no ritual patterns for language %q", language)
+		}
+		for name, phrases := range set {
+			for _, phrase := range phrases {
+				if words := wordTokens(phrase); len(words) > 0 {
+					patterns[name] = append(patterns[name], words)
+				}
+			}
+		}
+	}
+	return languages, patterns, nil
+}
+
+func containsAnyPhrase(words []string, phrases [][]string) bool {
+	for _, phrase := range phrases {
+		if countPhrase(words, phrase) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedDays(days map[int]bool) []int {
+	sorted := make([]int, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+// typicalMinuteOfDay returns the median of minutes on the 24-hour circle. The
+// circle is cut at the widest gap between observations, so a night ritual
+// spread over 23:30 and 00:30 has a typical time near midnight rather than noon.
+func typicalMinuteOfDay(minutes []int) int {
+	sorted := append([]int(nil), minutes...)
+	sort.Ints(sorted)
+
+	cut, widest := 0, sorted[0]+minutesPerDay-sorted[len(sorted)-1]
+	for i := 1; i < len(sorted); i++ {
+		if gap := sorted[i] - sorted[i-1]; gap > widest {
+			cut, widest = i, gap
+		}
+	}
+	unrolled := make([]int, len(sorted))
+	for i := range sorted {
+		minute := sorted[(cut+i)%len(sorted)]
+		if minute < sorted[cut] {
+			minute += minutesPerDay
+		}
+		unrolled[i] = minute
+	}
+
+	middle := len(unrolled) / 2
+	median := unrolled[middle]
+	if len(unrolled)%2 == 0 {
+		median = (unrolled[middle-1] + unrolled[middle]) / 2
+	}
+	return median % minutesPerDay
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestTypicalMinuteOfDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes []int
+		want    int
+	}{
+		{"single", []int{7*60 + 15}, 7*60 + 15},
+		{"daytime", []int{600, 660, 720}, 660},
+		{"straddles midnight", []int{23*60 + 30, 30}, 0},
+		{"mostly after midnight", []int{23*60 + 50, 10, 20}, 10},
+		{"wide gap before midnight", []int{22 * 60, 23 * 60, 23*60 + 30, 60}, 23*60 + 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := typicalMinuteOfDay(tt.minutes); got != tt.want {
+				t.Errorf("typicalMinuteOfDay(%v) = %s; want %s", tt.minutes, formatMinuteOfDay(got), formatMinuteOfDay(tt.want))
+			}
+		})
+	}
+}
+
+func TestRituals(t *testing.T) {
+	// Good nights sent after midnight still belong to the evening before
+	// with the default 04:00 day start.
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 23, 40), "Alice", "good night").
+		Add(date(2024, 1, 1, 23, 45), "Bob", "sweet dreams").
+		Add(date(2024, 1, 2, 8, 0), "Bob", "Good morning!").
+		Add(date(2024, 1, 3, 0, 10), "Bob", "gn").
+		Add(date(2024, 1, 3, 0, 20), "Alice", "good night ❤️").
+		Add(date(2024, 1, 3, 23, 50), "Alice", "goodnight")
+
+	report, err := newTestUsecase(t).Rituals(chat.Chat, RitualOptions{Languages: []string{"en"}, DayStartHour: 4, AsOf: date(2024, 1, 3, 0, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Rituals) != 2 || report.Rituals[0].Name != "morning" || report.Rituals[1].Name != "night" {
+		t.Fatalf("rituals = %+v; want morning and night", report.Rituals)
+	}
+
+	night := report.Rituals[1]
+	if night.Days != 3 || night.MutualDays != 2 {
+		t.Errorf("night days %d, mutual %d; want 3 and 2", night.Days, night.MutualDays)
+	}
+	tests := []struct {
+		person string
+		want   domain.RitualStats
+	}{
+		{"Alice", domain.RitualStats{Messages: 3, Days: 3, FirstDays: 2, Consistency: 1, TypicalTime: "23:50"}},
+		{"Bob", domain.RitualStats{Messages: 2, Days: 2, FirstDays: 1, Consistency: 1, TypicalTime: "23:57"}},
+	}
+	for _, tt := range tests {
+		if got := night.People[tt.person]; got != tt.want {
+			t.Errorf("%s = %+v; want %+v", tt.person, got, tt.want)
+		}
+	}
+	if got := night.Longest["overall"]; got.Start != "2024-01-01" || got.Length != 3 {
+		t.Errorf("longest overall = %+v; want 3 days from 2024-01-01", got)
+	}
+	if got := night.Current["Alice"]; got.Length != 3 {
+		t.Errorf("current Alice = %+v; want 3 days ending on the asOf day", got)
+	}
+	if got := report.Rituals[0].People["Bob"]; got.Days != 1 || got.TypicalTime != "08:00" {
+		t.Errorf("morning Bob = %+v; want one day at 08:00", got)
+	}
+}
+
+func TestRitualsRejectsUnknownLanguage(t *testing.T) {
+	_, err := newTestUsecase(t).Rituals(newTestChat().Chat, RitualOptions{Languages: []string{"xx"}})
+	if err == nil {
+		t.Fatal("expected an error for an unknown language")
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"fmt"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// TrendOptions controls how RelationshipScoreTrend slices the chat.
+type TrendOptions struct {
+	// Location is the timezone periods are aligned to. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Period is week, month or quarter.
+	Period string
+	// Window is how many periods each score covers, ending at the current
+	// period. 1 scores every period on its own; 3 with monthly periods gives
+	// a rolling quarter that advances one month at a time.
+	Window int
+}
+
+// RelationshipScoreTrend scores the chat once per period over a trailing
+// window. Windows without any messages are left out of the series. Every
+// window is scored in opts.Location, so the day-based components count the
+// same days the window bounds were cut on.
+func (u *messageUsecase) RelationshipScoreTrend(chat domain.Chat, weights domain.ScoreWeights, opts TrendOptions) ([]domain.ScorePoint, error) {
+	switch opts.Period {
+	case GranularityWeek, GranularityMonth, GranularityQuarter:
+	default:
+		return nil, fmt.Errorf("period must be week, month or quarter")
+	}
+	window := opts.Window
+	if window < 1 {
+		window = 1
+	}
+
+	points := []domain.ScorePoint{}
+	first, last, ok := chatTimeRange(chat, opts.Location)
+	if !ok {
+		return points, nil
+	}
+
+	lastPeriod := periodStart(last, opts.Period, time.Monday)
+	for period := periodStart(first, opts.Period, time.Monday); !period.After(lastPeriod); period = nextPeriod(period, opts.Period, 1) {
+		from := nextPeriod(period, opts.Period, 1-window)
+		to := nextPeriod(period, opts.Period, 1)
+
+		windowChat := chatBetween(chat, opts.Location, from, to)
+		if len(windowChat.Messages) == 0 {
+			continue
+		}
+
+		score, err := u.RelationshipScore(chatInLocation(windowChat, opts.Location), weights)
+		if err != nil {
+			return nil, fmt.Errorf("failed to score %s: %v", period.Format(dateLayout), err)
+		}
+		points = append(points, domain.ScorePoint{
+			PeriodStart: from.Format(dateLayout),
+			PeriodEnd:   to.AddDate(0, 0, -1).Format(dateLayout),
+			Messages:    len(windowChat.Messages),
+			Score:       score.Score,
+			Components:  score.Components,
+		})
+	}
+	return points, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRelationshipScoreTrendWindows(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 10, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 10, 9, 5), "Bob", "hey").
+		Add(date(2024, 2, 10, 9, 0), "Alice", "hi").
+		Add(date(2024, 4, 10, 9, 0), "Bob", "long time")
+
+	type point struct {
+		start, end string
+		messages   int
+	}
+	tests := []struct {
+		name   string
+		window int
+		want   []point
+	}{
+		{
+			name:   "one month",
+			window: 1,
+			want: []point{
+				{"2024-01-01", "2024-01-31", 2},
+				{"2024-02-01", "2024-02-29", 1},
+				{"2024-04-01", "2024-04-30", 1},
+			},
+		},
+		{
+			name:   "rolling quarter",
+			window: 3,
+			want: []point{
+				{"2023-11-01", "2024-01-31", 2},
+				{"2023-12-01", "2024-02-29", 3},
+				{"2024-01-01", "2024-03-31", 3},
+				{"2024-02-01", "2024-04-30", 2},
+			},
+		},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := u.RelationshipScoreTrend(chat.Chat, nil, TrendOptions{Period: GranularityMonth, Window: tt.window})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(points) != len(tt.want) {
+				t.Fatalf("got %d points %+v; want %d", len(points), points, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := points[i]
+				if got.PeriodStart != want.start || got.PeriodEnd != want.end || got.Messages != want.messages {
+					t.Errorf("point %d = %s..%s with %d messages; want %s..%s with %d", i, got.PeriodStart, got.PeriodEnd, got.Messages, want.start, want.end, want.messages)
+				}
+			}
+		})
+	}
+}
+
+func TestRelationshipScoreTrendRejectsUnknownPeriod(t *testing.T) {
+	_, err := newTestUsecase(t).RelationshipScoreTrend(newTestChat().Chat, nil, TrendOptions{Period: "day"})
+	if err == nil {
+		t.Fatal("expected an error for period=day")
+	}
+}
+
+func TestRelationshipScoreTrendScoresInLocation(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 10, 22, 30), "Alice", "hi").
+		Add(date(2024, 1, 10, 23, 0), "Bob", "hey").
+		Add(date(2024, 1, 11, 1, 0), "Alice", "still up?").
+		Add(date(2024, 1, 11, 1, 5), "Bob", "yes")
+	for i, message := range chat.Messages {
+		sentAt, _ := time.Parse(dateTimeLayout, message.Date)
+		chat.Messages[i].DateUnixtime = strconv.FormatInt(sentAt.Unix(), 10)
+	}
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	// Replies during 23:00-04:00 are ignored, so only the 04:05 reply counts
+	// once the messages are read in Moscow time.
+	tests := []struct {
+		name      string
+		loc       *time.Location
+		replyTime float64
+	}{
+		{"wall clock", nil, 0},
+		{"moscow", moscow, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := newTestUsecase(t).RelationshipScoreTrend(chat.Chat, DefaultScoreWeights(), TrendOptions{Period: GranularityMonth, Location: tt.loc})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(points) != 1 {
+				t.Fatalf("points = %+v; want one", points)
+			}
+			for _, component := range points[0].Components {
+				if component.Name == ComponentReplyTime && component.Input != tt.replyTime {
+					t.Errorf("reply time input = %v; want %v", component.Input, tt.replyTime)
+				}
+			}
+		})
+	}
+}
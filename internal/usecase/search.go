@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// SearchOptions controls Search. Zero From and To leave the date range open.
+type SearchOptions struct {
+	// Phrases are the query's terms; a phrase of several words must match
+	// consecutive words. Every phrase has to match for a message to be a hit.
+	Phrases [][]string
+	// Sender keeps only messages from this participant when set.
+	Sender string
+	// Location is the timezone used for the date range. Nil keeps the export's wall clock.
+	Location *time.Location
+	// From and To are the first and last day, inclusive, to search in.
+	From time.Time
+	To   time.Time
+	// Context is how many messages before and after each hit to return.
+	Context int
+	// Limit and Offset page through the hits.
+	Limit  int
+	Offset int
+}
+
+// ParseSearchQuery splits a query into words and "quoted phrases",
+// normalized the same way BuildSearchIndex normalizes message text.
+func ParseSearchQuery(query string) ([][]string, error) {
+	var phrases [][]string
+	parts := strings.Split(query, `"`)
+	if len(parts)%2 == 0 {
+		return nil, fmt.Errorf("unbalanced quote in query")
+	}
+	for i, part := range parts {
+		words := wordTokens(part)
+		if len(words) == 0 {
+			continue
+		}
+		if i%2 == 1 {
+			phrases = append(phrases, words)
+			continue
+		}
+		for _, word := range words {
+			phrases = append(phrases, []string{word})
+		}
+	}
+	if len(phrases) == 0 {
+		return nil, fmt.Errorf("query has no searchable words")
+	}
+	return phrases, nil
+}
+
+// SearchTerms lists the distinct terms whose postings Search needs.
+func SearchTerms(phrases [][]string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, phrase := range phrases {
+		for _, word := range phrase {
+			if !seen[word] {
+				seen[word] = true
+				terms = append(terms, word)
+			}
+		}
+	}
+	return terms
+}
+
+// BuildSearchIndex maps every word of every message to the messages and word
+// positions where it occurs.
+func (u *messageUsecase) BuildSearchIndex(chat domain.Chat) domain.SearchIndex {
+	index := make(domain.SearchIndex)
+	for i, message := range chat.Messages {
+		if isServiceMessage(message) {
+			continue
+		}
+		positions := make(map[string][]int)
+		var order []string
+		for position, word := range wordTokens(messageText(message)) {
+			if positions[word] == nil {
+				order = append(order, word)
+			}
+			positions[word] = append(positions[word], position)
+		}
+		for _, word := range order {
+			index[word] = append(index[word], domain.Posting{Message: i, Positions: positions[word]})
+		}
+	}
+	return index
+}
+
+// MessageLoader returns the messages at the given positions of a chat's
+// Messages, leaving out positions past its end.
+type MessageLoader func(positions []int) (map[int]domain.Message, error)
+
+// ChatMessages loads messages from a chat that is already in memory.
+func ChatMessages(chat domain.Chat) MessageLoader {
+	return func(positions []int) (map[int]domain.Message, error) {
+		messages := make(map[int]domain.Message, len(positions))
+		for _, position := range positions {
+			if position >= 0 && position < len(chat.Messages) {
+				messages[position] = chat.Messages[position]
+			}
+		}
+		return messages, nil
+	}
+}
+
+// Search answers a query from the postings of its terms, which must come from
+// an index built over the chat load reads. Only the messages that contain
+// every phrase and the context around the returned hits are loaded. Hits are
+// returned in chronological order.
+func (u *messageUsecase) Search(postings domain.SearchIndex, load MessageLoader, opts SearchOptions) (domain.SearchResult, error) {
+	if len(opts.Phrases) == 0 {
+		return domain.SearchResult{}, fmt.Errorf("query has no searchable words")
+	}
+
+	var candidates map[int]bool
+	for _, phrase := range opts.Phrases {
+		matches := phraseMatches(phrase, postings)
+		if candidates == nil {
+			candidates = matches
+			continue
+		}
+		for message := range candidates {
+			if !matches[message] {
+				delete(candidates, message)
+			}
+		}
+	}
+
+	cache := &messageCache{load: load, messages: make(map[int]domain.Message), tried: make(map[int]bool)}
+	positions := make([]int, 0, len(candidates))
+	for i := range candidates {
+		positions = append(positions, i)
+	}
+	if err := cache.fetch(positions); err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	type hit struct {
+		index int
+		at    time.Time
+	}
+	var hits []hit
+	for _, i := range positions {
+		message, ok := cache.messages[i]
+		if !ok {
+			continue
+		}
+		if opts.Sender != "" && message.From != opts.Sender {
+			continue
+		}
+		sentAt, err := messageTime(message, opts.Location)
+		if err != nil {
+			continue
+		}
+		day := dayNumber(sentAt)
+		if (!opts.From.IsZero() && day < dayNumber(opts.From)) || (!opts.To.IsZero() && day > dayNumber(opts.To)) {
+			continue
+		}
+		hits = append(hits, hit{index: i, at: sentAt})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if !hits[i].at.Equal(hits[j].at) {
+			return hits[i].at.Before(hits[j].at)
+		}
+		return hits[i].index < hits[j].index
+	})
+
+	result := domain.SearchResult{Total: len(hits), Hits: []domain.SearchHit{}}
+	if opts.Offset >= len(hits) {
+		return result, nil
+	}
+	hits = hits[opts.Offset:]
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+
+	// Load the context of the whole page at once.
+	var window []int
+	for _, h := range hits {
+		for position := h.index - opts.Context; position <= h.index+opts.Context; position++ {
+			window = append(window, position)
+		}
+	}
+	if err := cache.fetch(window); err != nil {
+		return domain.SearchResult{}, err
+	}
+	for _, h := range hits {
+		before, err := contextMessages(cache, h.index, -1, opts.Context)
+		if err != nil {
+			return domain.SearchResult{}, err
+		}
+		after, err := contextMessages(cache, h.index, 1, opts.Context)
+		if err != nil {
+			return domain.SearchResult{}, err
+		}
+		result.Hits = append(result.Hits, domain.SearchHit{
+			Message: searchMessage(cache.messages[h.index]),
+			Before:  before,
+			After:   after,
+		})
+	}
+	return result, nil
+}
+
+// messageCache remembers loaded messages, and which positions were asked
+// for, so overlapping context windows are loaded once.
+type messageCache struct {
+	load     MessageLoader
+	messages map[int]domain.Message
+	tried    map[int]bool
+}
+
+func (c *messageCache) fetch(positions []int) error {
+	var missing []int
+	for _, position := range positions {
+		if position >= 0 && !c.tried[position] {
+			c.tried[position] = true
+			missing = append(missing, position)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	loaded, err := c.load(missing)
+	if err != nil {
+		return err
+	}
+	for position, message := range loaded {
+		c.messages[position] = message
+	}
+	return nil
+}
+
+// phraseMatches returns the messages containing the words of phrase at
+// consecutive positions.
+func phraseMatches(phrase []string, postings domain.SearchIndex) map[int]bool {
+	matches := make(map[int]bool)
+	// starts maps each message to the positions where the phrase may begin.
+	starts := make(map[int]map[int]bool)
+	for _, posting := range postings[phrase[0]] {
+		starts[posting.Message] = make(map[int]bool, len(posting.Positions))
+		for _, position := range posting.Positions {
+			starts[posting.Message][position] = true
+		}
+	}
+	for offset, word := range phrase[1:] {
+		next := make(map[int]map[int]bool)
+		for _, posting := range postings[word] {
+			candidates := starts[posting.Message]
+			if candidates == nil {
+				continue
+			}
+			for _, position := range posting.Positions {
+				start := position - offset - 1
+				if candidates[start] {
+					if next[posting.Message] == nil {
+						next[posting.Message] = make(map[int]bool)
+					}
+					next[posting.Message][start] = true
+				}
+			}
+		}
+		starts = next
+	}
+	for message := range starts {
+		matches[message] = true
+	}
+	return matches
+}
+
+// contextMessages walks from the hit in direction step and collects up to
+// count neighbouring messages, skipping service entries.
+func contextMessages(cache *messageCache, index, step, count int) ([]domain.SearchMessage, error) {
+	messages := []domain.SearchMessage{}
+	for i := index + step; i >= 0 && len(messages) < count; i += step {
+		if !cache.tried[i] {
+			// Service entries used up part of the window; load what is
+			// still missing.
+			var window []int
+			for j := i; len(window) < count-len(messages); j += step {
+				window = append(window, j)
+			}
+			if err := cache.fetch(window); err != nil {
+				return nil, err
+			}
+		}
+		message, ok := cache.messages[i]
+		if !ok {
+			break
+		}
+		if isServiceMessage(message) {
+			continue
+		}
+		messages = append(messages, searchMessage(message))
+	}
+	if step < 0 {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+	return messages, nil
+}
+
+func searchMessage(message domain.Message) domain.SearchMessage {
+	return domain.SearchMessage{
+		ID:   message.ID,
+		From: message.From,
+		Date: message.Date,
+		Text: messageText(message),
+	}
+}
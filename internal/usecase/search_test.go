@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    [][]string
+		wantErr bool
+	}{
+		{"pizza", [][]string{{"pizza"}}, false},
+		{"Pizza tonight", [][]string{{"pizza"}, {"tonight"}}, false},
+		{`"love you" pizza`, [][]string{{"love", "you"}, {"pizza"}}, false},
+		{`"good night" "love you"`, [][]string{{"good", "night"}, {"love", "you"}}, false},
+		{`"love you`, nil, true},
+		{`""`, nil, true},
+		{"", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ParseSearchQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v; want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("phrases = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhraseMatches(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "i love you").
+		Add(date(2024, 1, 1, 9, 1), "Bob", "you love me").
+		Add(date(2024, 1, 1, 9, 2), "Alice", "love love you too").
+		Add(date(2024, 1, 1, 9, 3), "Bob", "love and you")
+	index := newTestUsecase(t).BuildSearchIndex(chat.Chat)
+
+	tests := []struct {
+		phrase string
+		want   map[int]bool
+	}{
+		{"love", map[int]bool{0: true, 1: true, 2: true, 3: true}},
+		{"love you", map[int]bool{0: true, 2: true}},
+		{"i love you", map[int]bool{0: true}},
+		{"love love you", map[int]bool{2: true}},
+		{"you love", map[int]bool{1: true}},
+		{"you too", map[int]bool{2: true}},
+		{"love me too", map[int]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			if got := phraseMatches(wordTokens(tt.phrase), index); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("phraseMatches = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSearchIndex(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "ha ha, pizza!").
+		Service(date(2024, 1, 1, 9, 1), "phone_call", 60).
+		Add(date(2024, 1, 1, 9, 2), "Bob", "Pizza 🍕")
+
+	index := newTestUsecase(t).BuildSearchIndex(chat.Chat)
+	if want := 2; len(index) != want {
+		t.Errorf("terms = %v; want ha and pizza only", index)
+	}
+	if got := index["ha"]; len(got) != 1 || got[0].Message != 0 || !reflect.DeepEqual(got[0].Positions, []int{0, 1}) {
+		t.Errorf("ha postings = %+v; want message 0 at positions 0 and 1", got)
+	}
+	if got := index["pizza"]; len(got) != 2 || got[1].Message != 2 || !reflect.DeepEqual(got[1].Positions, []int{0}) {
+		t.Errorf("pizza postings = %+v; want messages 0 and 2", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	chat := newTestChat()
+	for day := 1; day <= 5; day++ {
+		chat.Add(date(2024, 1, day, 9, 0), "Alice", "good night love you").
+			Add(date(2024, 1, day, 9, 1), "Bob", "good night")
+	}
+	uc := newTestUsecase(t)
+	index := uc.BuildSearchIndex(chat.Chat)
+
+	tests := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+		total int
+		ids   []int
+	}{
+		{"phrase", `"good night"`, SearchOptions{}, 10, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{"several phrases", `"good night" "love you"`, SearchOptions{}, 5, []int{1, 3, 5, 7, 9}},
+		{"phrase and word", `"night love" good`, SearchOptions{}, 5, []int{1, 3, 5, 7, 9}},
+		{"no match", `"you love"`, SearchOptions{}, 0, []int{}},
+		{"limit", "night", SearchOptions{Limit: 3}, 10, []int{1, 2, 3}},
+		{"offset and limit", "night", SearchOptions{Offset: 4, Limit: 3}, 10, []int{5, 6, 7}},
+		{"offset past end", "night", SearchOptions{Offset: 10}, 10, []int{}},
+		{"sender", "night", SearchOptions{Sender: "Bob", Offset: 3}, 5, []int{8, 10}},
+		{"date range", "love", SearchOptions{From: date(2024, 1, 2, 0, 0), To: date(2024, 1, 3, 0, 0)}, 2, []int{3, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phrases, err := ParseSearchQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.opts.Phrases = phrases
+			result, err := uc.Search(index, ChatMessages(chat.Chat), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := []int{}
+			for _, hit := range result.Hits {
+				ids = append(ids, hit.Message.ID)
+			}
+			if result.Total != tt.total || !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("total %d, hits %v; want %d and %v", result.Total, ids, tt.total, tt.ids)
+			}
+		})
+	}
+}
+
+func TestSearchContext(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "one").
+		Service(date(2024, 1, 1, 9, 1), "phone_call", 60).
+		Add(date(2024, 1, 1, 9, 2), "Bob", "two").
+		Add(date(2024, 1, 1, 9, 3), "Alice", "pizza").
+		Add(date(2024, 1, 1, 9, 4), "Bob", "three")
+	uc := newTestUsecase(t)
+
+	result, err := uc.Search(uc.BuildSearchIndex(chat.Chat), ChatMessages(chat.Chat), SearchOptions{Phrases: [][]string{{"pizza"}}, Context: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Hits) != 1 {
+		t.Fatalf("hits = %+v; want one", result.Hits)
+	}
+	hit := result.Hits[0]
+	if len(hit.Before) != 2 || hit.Before[0].Text != "one" || hit.Before[1].Text != "two" || len(hit.After) != 1 {
+		t.Errorf("before %+v, after %+v; want one and two before, three after", hit.Before, hit.After)
+	}
+}
+
+func TestSearchLoadsOnlyHitsAndContext(t *testing.T) {
+	chat := newTestChat()
+	for i := 0; i < 50; i++ {
+		chat.Add(date(2024, 1, 1, 9, i), "Alice", "filler")
+	}
+	chat.Messages[10].Text = "pizza"
+	chat.Messages[11].Type = "service"
+	chat.Messages[40].Text = "pizza"
+	uc := newTestUsecase(t)
+
+	var loaded []int
+	load := func(positions []int) (map[int]domain.Message, error) {
+		loaded = append(loaded, positions...)
+		return ChatMessages(chat.Chat)(positions)
+	}
+	result, err := uc.Search(uc.BuildSearchIndex(chat.Chat), load, SearchOptions{Phrases: [][]string{{"pizza"}}, Context: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 2 || len(result.Hits) != 1 || result.Hits[0].Message.ID != 11 {
+		t.Fatalf("result = %+v; want the first of 2 hits", result)
+	}
+	// The service entry after the hit is skipped, so the window grows by one.
+	if after := result.Hits[0].After; len(after) != 1 || after[0].ID != 13 {
+		t.Errorf("after = %+v; want message 13", after)
+	}
+	sort.Ints(loaded)
+	if want := []int{9, 10, 11, 12, 40}; !reflect.DeepEqual(loaded, want) {
+		t.Errorf("loaded %v; want %v", loaded, want)
+	}
+}
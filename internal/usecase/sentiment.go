@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// SentimentOptions controls the lexicon-based sentiment analysis.
+type SentimentOptions struct {
+	// Location is the timezone used to group messages into days. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Languages picks which lexicons to use, e.g. "en", "am", "emoji". Empty uses all of them.
+	Languages []string
+	// IncludeMessages adds the polarity of every scored message to the report.
+	IncludeMessages bool
+	// MinDayMessages is how many scored messages a day needs to be ranked
+	// among the most positive or negative days.
+	MinDayMessages int
+	// TopDays is how many days to return in each ranking.
+	TopDays int
+}
+
+// negationFactor dampens and flips the score of a word that follows a negator,
+// so "not good" reads as mildly negative rather than strongly so.
+const negationFactor = -0.74
+
+func (u *messageUsecase) Sentiment(chat domain.Chat, opts SentimentOptions) (domain.SentimentReport, error) {
+	lexicons, err := u.selectLexicons(opts.Languages)
+	if err != nil {
+		return domain.SentimentReport{}, err
+	}
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+
+	report := domain.SentimentReport{
+		Averages:         map[string]float64{personOne: 0, personTwo: 0, "overall": 0},
+		ScoredMessages:   map[string]int{personOne: 0, personTwo: 0, "overall": 0},
+		Daily:            []domain.DailyMood{},
+		MostPositiveDays: []domain.DailyMood{},
+		MostNegativeDays: []domain.DailyMood{},
+	}
+	for _, lexicon := range lexicons {
+		report.Languages = append(report.Languages, lexicon.Language())
+	}
+
+	type moodSum struct {
+		sums   map[string]float64
+		counts map[string]int
+	}
+	totals := make(map[string]float64)
+	days := make(map[int]*moodSum)
+
+	for _, message := range chat.Messages {
+		if isServiceMessage(message) || (message.From != personOne && message.From != personTwo) {
+			continue
+		}
+		polarity, scored := messagePolarity(messageText(message), lexicons)
+		if !scored {
+			continue
+		}
+		sentAt, err := messageTime(message, opts.Location)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range []string{message.From, "overall"} {
+			totals[key] += polarity
+			report.ScoredMessages[key]++
+		}
+
+		day := dayNumber(sentAt)
+		if days[day] == nil {
+			days[day] = &moodSum{sums: make(map[string]float64), counts: make(map[string]int)}
+		}
+		for _, key := range []string{message.From, "overall"} {
+			days[day].sums[key] += polarity
+			days[day].counts[key]++
+		}
+
+		if opts.IncludeMessages {
+			report.Messages = append(report.Messages, domain.MessageSentiment{
+				ID:       message.ID,
+				From:     message.From,
+				Date:     sentAt.Format(dateTimeLayout),
+				Polarity: roundTo(polarity, 4),
+			})
+		}
+	}
+
+	for key, total := range totals {
+		report.Averages[key] = roundTo(total/float64(report.ScoredMessages[key]), 4)
+	}
+
+	dayNumbers := make([]int, 0, len(days))
+	for day := range days {
+		dayNumbers = append(dayNumbers, day)
+	}
+	sort.Ints(dayNumbers)
+	for _, day := range dayNumbers {
+		mood := domain.DailyMood{
+			Date:     dayToDate(day),
+			Messages: days[day].counts["overall"],
+			Average:  make(map[string]float64),
+		}
+		for key, sum := range days[day].sums {
+			mood.Average[key] = roundTo(sum/float64(days[day].counts[key]), 4)
+		}
+		report.Daily = append(report.Daily, mood)
+	}
+
+	var ranked []domain.DailyMood
+	for _, mood := range report.Daily {
+		if mood.Messages >= opts.MinDayMessages {
+			ranked = append(ranked, mood)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Average["overall"] > ranked[j].Average["overall"]
+	})
+	for i := 0; i < len(ranked) && i < opts.TopDays; i++ {
+		if ranked[i].Average["overall"] > 0 {
+			report.MostPositiveDays = append(report.MostPositiveDays, ranked[i])
+		}
+		if last := ranked[len(ranked)-1-i]; last.Average["overall"] < 0 {
+			report.MostNegativeDays = append(report.MostNegativeDays, last)
+		}
+	}
+
+	return report, nil
+}
+
+// selectLexicons returns the requested lexicons in a stable order.
+func (u *messageUsecase) selectLexicons(languages []string) ([]Lexicon, error) {
+	if len(languages) == 0 {
+		for language := range u.lexicons {
+			languages = append(languages, language)
+		}
+		sort.Strings(languages)
+	}
+
+	lexicons := make([]Lexicon, 0, len(languages))
+	for _, language := range languages {
+		lexicon, ok := u.lexicons[language]
+		if !ok {
+			return nil, fmt.Errorf("no sentiment lexicon for language %q", language)
+		}
+		lexicons = append(lexicons, lexicon)
+	}
+	return lexicons, nil
+}
+
+// messagePolarity sums the lexicon scores of the message's tokens and squashes
+// the total into (-1, 1). scored is false when no token was recognised.
+func messagePolarity(text string, lexicons []Lexicon) (polarity float64, scored bool) {
+	tokens := wordsAndEmoji(text)
+	sum := 0.0
+	for i, token := range tokens {
+		for _, lexicon := range lexicons {
+			score, ok := lexicon.Score(token)
+			if !ok {
+				continue
+			}
+			if i > 0 && lexicon.IsNegator(tokens[i-1]) {
+				score *= negationFactor
+			}
+			sum += score
+			scored = true
+			break
+		}
+	}
+	if !scored {
+		return 0, false
+	}
+	return sum / math.Sqrt(sum*sum+15), true
+}
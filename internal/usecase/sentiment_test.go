@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+var testLexicon = NewLexicon("test", domain.LexiconData{
+	Words:    map[string]float64{"good": 2, "great": 3, "bad": -2, "awful": -3},
+	Negators: []string{"not"},
+})
+
+func TestMessagePolarityNegation(t *testing.T) {
+	tests := []struct {
+		text     string
+		polarity float64
+		scored   bool
+	}{
+		{"good", 2 / math.Sqrt(4+15), true},
+		{"not good", -1.48 / math.Sqrt(1.48*1.48+15), true},
+		{"not bad", 1.48 / math.Sqrt(1.48*1.48+15), true},
+		{"good not", 2 / math.Sqrt(4+15), true},
+		{"hello there", 0, false},
+	}
+	lexicons := []Lexicon{testLexicon}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			polarity, scored := messagePolarity(tt.text, lexicons)
+			if scored != tt.scored || math.Abs(polarity-tt.polarity) > 1e-9 {
+				t.Fatalf("messagePolarity(%q) = %v, %v; want %v, %v", tt.text, polarity, scored, tt.polarity, tt.scored)
+			}
+		})
+	}
+}
+
+func TestSentimentRanksDays(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 3, 1, 10, 0), "Alice", "great").
+		Add(date(2024, 3, 1, 10, 5), "Bob", "good").
+		Add(date(2024, 3, 2, 10, 0), "Alice", "good").
+		Add(date(2024, 3, 2, 10, 5), "Bob", "bad").
+		Add(date(2024, 3, 3, 10, 0), "Alice", "awful").
+		Add(date(2024, 3, 3, 10, 5), "Bob", "not good").
+		Add(date(2024, 3, 4, 10, 0), "Alice", "awful")
+
+	u := NewMessageUsecase(Config{Lexicons: []Lexicon{testLexicon}}).(*messageUsecase)
+	report, err := u.Sentiment(chat.Chat, SentimentOptions{Languages: []string{"test"}, MinDayMessages: 2, TopDays: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Daily) != 4 {
+		t.Fatalf("daily = %+v; want 4 days", report.Daily)
+	}
+	// March 4 has a single message, below MinDayMessages, so it is not ranked.
+	// March 2 averages to zero, so it is neither positive nor negative.
+	if got := report.MostPositiveDays; len(got) != 1 || got[0].Date != "2024-03-01" {
+		t.Errorf("most positive days = %+v; want only 2024-03-01", got)
+	}
+	if got := report.MostNegativeDays; len(got) != 1 || got[0].Date != "2024-03-03" {
+		t.Errorf("most negative days = %+v; want only 2024-03-03", got)
+	}
+	if report.ScoredMessages["Alice"] != 4 || report.ScoredMessages["overall"] != 7 {
+		t.Errorf("scored messages = %v; want 4 for Alice and 7 overall", report.ScoredMessages)
+	}
+}
+
+func TestSentimentRejectsUnknownLanguage(t *testing.T) {
+	_, err := newTestUsecase(t).Sentiment(newTestChat().Chat, SentimentOptions{Languages: []string{"xx"}})
+	if err == nil {
+		t.Fatal("expected an error for an unknown lexicon language")
+	}
+}
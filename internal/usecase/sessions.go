@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// DefaultSessionGap is how long a silence must last before the next message
+// starts a new conversation session.
+const DefaultSessionGap = time.Hour
+
+// timedMessage is a participant message together with its parsed send time.
+type timedMessage struct {
+	domain.Message
+	At time.Time
+}
+
+// session is a run of messages with no silence longer than the session gap.
+type session struct {
+	Messages []timedMessage
+}
+
+func (s session) Start() time.Time {
+	return s.Messages[0].At
+}
+
+func (s session) End() time.Time {
+	return s.Messages[len(s.Messages)-1].At
+}
+
+// Conversation describes the session for reports.
+func (s session) Conversation() domain.Conversation {
+	return domain.Conversation{
+		Start:    s.Start().Format(dateTimeLayout),
+		End:      s.End().Format(dateTimeLayout),
+		Messages: len(s.Messages),
+		Minutes:  int(s.End().Sub(s.Start()).Minutes()),
+	}
+}
+
+// replyDelay returns how long messages[i] took to answer the message before
+// it. Only a message from the other participant within gap is a reply.
+func replyDelay(messages []timedMessage, i int, gap time.Duration) (time.Duration, bool) {
+	if i == 0 || messages[i].From == messages[i-1].From {
+		return 0, false
+	}
+	delay := messages[i].At.Sub(messages[i-1].At)
+	return delay, delay <= gap
+}
+
+// sortedMessages returns the participants' messages ordered by send time,
+// skipping service entries and messages whose date cannot be parsed. The
+// chat itself is not modified.
+func (u *messageUsecase) sortedMessages(chat domain.Chat, loc *time.Location) []timedMessage {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+
+	messages := make([]timedMessage, 0, len(chat.Messages))
+	for _, message := range chat.Messages {
+		if isServiceMessage(message) || (message.From != personOne && message.From != personTwo) {
+			continue
+		}
+		sentAt, err := messageTime(message, loc)
+		if err != nil {
+			continue
+		}
+		messages = append(messages, timedMessage{Message: message, At: sentAt})
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].At.Before(messages[j].At)
+	})
+	return messages
+}
+
+// splitSessions groups time-ordered messages into sessions separated by
+// silences longer than gap.
+func splitSessions(messages []timedMessage, gap time.Duration) []session {
+	var sessions []session
+	for i, message := range messages {
+		if i == 0 || message.At.Sub(messages[i-1].At) > gap {
+			sessions = append(sessions, session{})
+		}
+		current := &sessions[len(sessions)-1]
+		current.Messages = append(current.Messages, message)
+	}
+	return sessions
+}
+
+// turn is a run of consecutive messages from one participant, ended by a
+// message from the other participant.
+type turn struct {
+	From     string
+	Messages []timedMessage
+}
+
+func (t turn) Start() time.Time {
+	return t.Messages[0].At
+}
+
+func (t turn) End() time.Time {
+	return t.Messages[len(t.Messages)-1].At
+}
+
+// splitTurns groups time-ordered messages into turns. A silence longer than
+// gap also ends a turn, so one person's messages days apart are not one burst.
+func splitTurns(messages []timedMessage, gap time.Duration) []turn {
+	var turns []turn
+	for i, message := range messages {
+		if i == 0 || message.From != messages[i-1].From || message.At.Sub(messages[i-1].At) > gap {
+			turns = append(turns, turn{From: message.From})
+		}
+		current := &turns[len(turns)-1]
+		current.Messages = append(current.Messages, message)
+	}
+	return turns
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// SilenceOptions controls Silences.
+type SilenceOptions struct {
+	// Location is the timezone used for dates and months. Nil keeps the export's wall clock.
+	Location *time.Location
+	// LongSilence is how long a gap must last to be counted per month and broken.
+	LongSilence time.Duration
+	// LeftOnRead is how long a message may go unanswered before it counts as left on read.
+	LeftOnRead time.Duration
+	// Top is how many of the longest silences to return.
+	Top int
+	// AsOf is when the chat was exported; the silence after the last
+	// message lasts until then. Zero leaves that silence out.
+	AsOf time.Time
+}
+
+// Silences walks the time-ordered messages and measures every gap between
+// consecutive ones, plus the silence from the last message until AsOf when it
+// is set: the longest silences and who broke them, who left whom on read, and
+// how many long silences began in each month. A message is left on read when
+// the other participant takes longer than LeftOnRead to answer it.
+func (u *messageUsecase) Silences(chat domain.Chat, opts SilenceOptions) (domain.SilenceReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+
+	report := domain.SilenceReport{
+		Longest: []domain.Silence{},
+		People:  map[string]domain.SilenceStats{personOne: {}, personTwo: {}},
+		Periods: []string{},
+		Monthly: []int{},
+	}
+	messages := u.sortedMessages(chat, opts.Location)
+	if len(messages) == 0 {
+		return report, nil
+	}
+
+	first := periodStart(messages[0].At, GranularityMonth, time.Monday)
+	last := periodStart(messages[len(messages)-1].At, GranularityMonth, time.Monday)
+	monthIndex := make(map[string]int)
+	for month := first; !month.After(last); month = nextPeriod(month, GranularityMonth, 1) {
+		key := month.Format(dateLayout)
+		monthIndex[key] = len(report.Periods)
+		report.Periods = append(report.Periods, key)
+	}
+	report.Monthly = make([]int, len(report.Periods))
+
+	// A gap without after is the silence that has not been broken yet.
+	type gap struct {
+		before timedMessage
+		after  *timedMessage
+		end    time.Time
+		length time.Duration
+	}
+	gaps := make([]gap, 0, len(messages))
+	record := func(g gap) {
+		gaps = append(gaps, g)
+		if g.length > opts.LongSilence {
+			report.Monthly[monthIndex[periodStart(g.before.At, GranularityMonth, time.Monday).Format(dateLayout)]]++
+			if g.after != nil {
+				stats := report.People[g.after.From]
+				stats.Broken++
+				report.People[g.after.From] = stats
+			}
+		}
+		if g.length > opts.LeftOnRead && (g.after == nil || g.after.From != g.before.From) {
+			sender := report.People[g.before.From]
+			sender.LeftOnRead++
+			report.People[g.before.From] = sender
+
+			other := personOne
+			if g.before.From == personOne {
+				other = personTwo
+			}
+			receiver := report.People[other]
+			receiver.LeftOtherOnRead++
+			report.People[other] = receiver
+		}
+	}
+	for i := 1; i < len(messages); i++ {
+		before, after := messages[i-1], messages[i]
+		record(gap{before: before, after: &after, end: after.At, length: after.At.Sub(before.At)})
+	}
+	if lastMessage := messages[len(messages)-1]; opts.AsOf.After(lastMessage.At) {
+		record(gap{before: lastMessage, end: opts.AsOf, length: opts.AsOf.Sub(lastMessage.At)})
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return gaps[i].length > gaps[j].length
+	})
+	if len(gaps) > opts.Top {
+		gaps = gaps[:opts.Top]
+	}
+	for _, g := range gaps {
+		silence := domain.Silence{
+			Start:    g.before.At.Format(dateTimeLayout),
+			End:      g.end.In(g.before.At.Location()).Format(dateTimeLayout),
+			Hours:    roundTo(g.length.Hours(), 2),
+			LastFrom: g.before.From,
+		}
+		if g.after != nil {
+			silence.BrokenBy = g.after.From
+		}
+		report.Longest = append(report.Longest, silence)
+	}
+	return report, nil
+}
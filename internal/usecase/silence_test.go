@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestSilences(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 10, 0), "Alice", "morning").
+		Add(date(2024, 1, 1, 10, 5), "Bob", "hey").
+		Add(date(2024, 1, 2, 16, 5), "Alice", "sorry, busy day").
+		Add(date(2024, 1, 3, 5, 5), "Bob", "same").
+		Add(date(2024, 3, 1, 9, 0), "Bob", "still alive?")
+
+	report, err := newTestUsecase(t).Silences(chat.Chat, SilenceOptions{LongSilence: 24 * time.Hour, LeftOnRead: 12 * time.Hour, Top: 2, AsOf: date(2024, 3, 1, 10, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bob's "hey" waits 30h and Alice's apology 13h for an answer. Bob's
+	// "same" is followed by his own message in March, so nobody left him on
+	// read, but that gap and the 30h one are long silences.
+	want := map[string]domain.SilenceStats{
+		"Alice": {Broken: 1, LeftOnRead: 1, LeftOtherOnRead: 1},
+		"Bob":   {Broken: 1, LeftOnRead: 1, LeftOtherOnRead: 1},
+	}
+	if !reflect.DeepEqual(report.People, want) {
+		t.Errorf("people = %+v; want %+v", report.People, want)
+	}
+	if !reflect.DeepEqual(report.Monthly, []int{2, 0, 0}) {
+		t.Errorf("monthly = %v; want both long silences in January", report.Monthly)
+	}
+	if len(report.Longest) != 2 {
+		t.Fatalf("longest = %+v; want 2 silences", report.Longest)
+	}
+	if got := report.Longest[0]; got.LastFrom != "Bob" || got.BrokenBy != "Bob" || got.Start != "2024-01-03T05:05:00" {
+		t.Errorf("longest silence = %+v; want Bob's gap from 2024-01-03 05:05", got)
+	}
+	if got := report.Longest[1]; got.Hours != 30 || got.LastFrom != "Bob" || got.BrokenBy != "Alice" {
+		t.Errorf("second silence = %+v; want 30h broken by Alice", got)
+	}
+}
+
+func TestSilencesCountsUnansweredLastMessage(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 10, 0), "Alice", "morning").
+		Add(date(2024, 1, 1, 10, 5), "Bob", "are you mad at me?")
+
+	report, err := newTestUsecase(t).Silences(chat.Chat, SilenceOptions{LongSilence: 24 * time.Hour, LeftOnRead: 12 * time.Hour, Top: 5, AsOf: date(2024, 1, 3, 10, 5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]domain.SilenceStats{
+		"Alice": {LeftOtherOnRead: 1},
+		"Bob":   {LeftOnRead: 1},
+	}
+	if !reflect.DeepEqual(report.People, want) {
+		t.Errorf("people = %+v; want %+v", report.People, want)
+	}
+	if !reflect.DeepEqual(report.Monthly, []int{1}) {
+		t.Errorf("monthly = %v; want the open silence in January", report.Monthly)
+	}
+	if got := report.Longest[0]; got.Hours != 48 || got.LastFrom != "Bob" || got.BrokenBy != "" || got.End != "2024-01-03T10:05:00" {
+		t.Errorf("longest silence = %+v; want Bob's unanswered 48h", got)
+	}
+}
+
+func TestSilencesLeftOnReadNeedsTheOtherPerson(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 0, 0), "Alice", "hi").
+		Add(date(2024, 1, 1, 13, 0), "Alice", "hello?").
+		Add(date(2024, 1, 2, 2, 0), "Bob", "sorry").
+		Add(date(2024, 1, 2, 3, 0), "Alice", "np").
+		Add(date(2024, 1, 2, 14, 0), "Alice", "so?").
+		Add(date(2024, 1, 3, 1, 0), "Bob", "yes")
+
+	// Without AsOf the silence after Bob's "yes" is left out.
+	report, err := newTestUsecase(t).Silences(chat.Chat, SilenceOptions{LongSilence: 24 * time.Hour, LeftOnRead: 12 * time.Hour, Top: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only "hello?" waited more than 12h for Bob; the 13h gap before it and
+	// the 11h gap after "so?" do not count.
+	want := map[string]domain.SilenceStats{
+		"Alice": {LeftOnRead: 1},
+		"Bob":   {LeftOtherOnRead: 1},
+	}
+	if !reflect.DeepEqual(report.People, want) {
+		t.Errorf("people = %+v; want %+v", report.People, want)
+	}
+	if len(report.Longest) != 5 {
+		t.Errorf("longest = %+v; want the 5 gaps between messages", report.Longest)
+	}
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+)
+
+// quantile interpolates linearly between the closest ranks of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+// summarize describes values without modifying them.
+func summarize(values []float64) domain.Distribution {
+	if len(values) == 0 {
+		return domain.Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	return domain.Distribution{
+		Count:  len(sorted),
+		Mean:   roundTo(sum/float64(len(sorted)), 2),
+		Min:    sorted[0],
+		P25:    roundTo(quantile(sorted, 0.25), 2),
+		Median: roundTo(quantile(sorted, 0.5), 2),
+		P75:    roundTo(quantile(sorted, 0.75), 2),
+		P90:    roundTo(quantile(sorted, 0.9), 2),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return quantile(sorted, 0.5)
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// StreakOptions controls how days are bucketed and which streaks are reported.
+type StreakOptions struct {
+	// Location is the timezone used to decide which day a message belongs to.
+	// Nil keeps the wall clock stored in the export.
+	Location *time.Location
+	// AsOf is the reference date for current streaks. Zero means now, read
+	// in Location or, without one, in UTC rather than the server's timezone.
+	AsOf time.Time
+	// GraceDays is how many silent days may follow the last active day
+	// before the current streak counts as broken.
+	GraceDays int
+	// MinLength drops streaks shorter than this from StreakReport.Streaks.
+	MinLength int
+}
+
+func (u *messageUsecase) CountConsecutiveDays(chat domain.Chat, opts StreakOptions) (map[string]domain.Streak, error) {
+	daysByPerson := u.activeDaysByPerson(chat, opts.Location)
+
+	longest := make(map[string]domain.Streak)
+	for person, days := range daysByPerson {
+		longest[person] = longestStreak(findStreaks(days))
+	}
+	return longest, nil
+}
+
+func (u *messageUsecase) CurrentStreak(chat domain.Chat, opts StreakOptions) (map[string]domain.Streak, error) {
+	daysByPerson := u.activeDaysByPerson(chat, opts.Location)
+	asOfDay := asOfDayNumber(opts)
+
+	current := make(map[string]domain.Streak)
+	for person, days := range daysByPerson {
+		current[person] = currentStreak(days, asOfDay, opts.GraceDays)
+	}
+	return current, nil
+}
+
+func (u *messageUsecase) Streaks(chat domain.Chat, opts StreakOptions) (domain.StreakReport, error) {
+	daysByPerson := u.activeDaysByPerson(chat, opts.Location)
+	asOfDay := asOfDayNumber(opts)
+
+	report := domain.StreakReport{
+		Longest: make(map[string]domain.Streak),
+		Current: make(map[string]domain.Streak),
+		Streaks: make(map[string][]domain.Streak),
+	}
+	for person, days := range daysByPerson {
+		streaks := findStreaks(days)
+		report.Longest[person] = longestStreak(streaks)
+		report.Current[person] = currentStreak(days, asOfDay, opts.GraceDays)
+
+		filtered := []domain.Streak{}
+		for _, streak := range streaks {
+			if streak.Length >= opts.MinLength {
+				filtered = append(filtered, streak)
+			}
+		}
+		report.Streaks[person] = filtered
+	}
+	return report, nil
+}
+
+// activeDaysByPerson returns the sorted, de-duplicated day numbers on which
+// each participant sent a message, plus "overall" for the whole chat.
+// The chat's message slice is left untouched.
+func (u *messageUsecase) activeDaysByPerson(chat domain.Chat, loc *time.Location) map[string][]int {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+
+	daySets := map[string]map[int]struct{}{
+		personOne: {},
+		personTwo: {},
+		"overall": {},
+	}
+	for _, message := range chat.Messages {
+		sentAt, err := messageTime(message, loc)
+		if err != nil {
+			continue
+		}
+		day := dayNumber(sentAt)
+		if _, exists := daySets[message.From]; !exists {
+			daySets[message.From] = make(map[int]struct{})
+		}
+		daySets[message.From][day] = struct{}{}
+		daySets["overall"][day] = struct{}{}
+	}
+
+	result := make(map[string][]int, len(daySets))
+	for person, set := range daySets {
+		days := make([]int, 0, len(set))
+		for day := range set {
+			days = append(days, day)
+		}
+		sort.Ints(days)
+		result[person] = days
+	}
+	return result
+}
+
+// findStreaks splits sorted day numbers into runs of consecutive days.
+func findStreaks(days []int) []domain.Streak {
+	var streaks []domain.Streak
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && days[j+1] == days[j]+1 {
+			j++
+		}
+		streaks = append(streaks, domain.Streak{
+			Start:  dayToDate(days[i]),
+			End:    dayToDate(days[j]),
+			Length: j - i + 1,
+		})
+		i = j + 1
+	}
+	return streaks
+}
+
+// longestStreak returns the longest streak, preferring the most recent on ties.
+func longestStreak(streaks []domain.Streak) domain.Streak {
+	var longest domain.Streak
+	for _, streak := range streaks {
+		if streak.Length >= longest.Length {
+			longest = streak
+		}
+	}
+	return longest
+}
+
+// currentStreak returns the streak ending on the last active day on or
+// before asOfDay, provided that day is no more than graceDays in the past.
+func currentStreak(days []int, asOfDay, graceDays int) domain.Streak {
+	last := sort.SearchInts(days, asOfDay+1) - 1
+	if last < 0 || asOfDay-days[last] > graceDays {
+		return domain.Streak{}
+	}
+
+	first := last
+	for first > 0 && days[first-1] == days[first]-1 {
+		first--
+	}
+	return domain.Streak{
+		Start:  dayToDate(days[first]),
+		End:    dayToDate(days[last]),
+		Length: last - first + 1,
+	}
+}
+
+func asOfDayNumber(opts StreakOptions) int {
+	asOf := opts.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	if opts.Location != nil {
+		asOf = asOf.In(opts.Location)
+	}
+	return dayNumber(asOf)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestFindStreaks(t *testing.T) {
+	day := dayNumber(date(2024, 1, 1, 0, 0))
+	got := findStreaks([]int{day, day + 1, day + 2, day + 5, day + 7, day + 8})
+	want := []domain.Streak{
+		{Start: "2024-01-01", End: "2024-01-03", Length: 3},
+		{Start: "2024-01-06", End: "2024-01-06", Length: 1},
+		{Start: "2024-01-08", End: "2024-01-09", Length: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findStreaks = %+v; want %+v", got, want)
+	}
+	if longest := longestStreak(append(got, domain.Streak{Start: "2024-02-01", End: "2024-02-03", Length: 3})); longest.Start != "2024-02-01" {
+		t.Errorf("longestStreak = %+v; want the most recent of the tied streaks", longest)
+	}
+}
+
+func TestCurrentStreak(t *testing.T) {
+	day := dayNumber(date(2024, 1, 1, 0, 0))
+	days := []int{day, day + 1, day + 2, day + 6, day + 7}
+	tests := []struct {
+		name  string
+		asOf  int
+		grace int
+		want  domain.Streak
+	}{
+		{"ends today", day + 7, 0, domain.Streak{Start: "2024-01-07", End: "2024-01-08", Length: 2}},
+		{"broken yesterday", day + 8, 0, domain.Streak{}},
+		{"within grace", day + 9, 2, domain.Streak{Start: "2024-01-07", End: "2024-01-08", Length: 2}},
+		{"past grace", day + 10, 2, domain.Streak{}},
+		{"as of the past", day + 2, 0, domain.Streak{Start: "2024-01-01", End: "2024-01-03", Length: 3}},
+		{"before any message", day - 1, 5, domain.Streak{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentStreak(days, tt.asOf, tt.grace); got != tt.want {
+				t.Errorf("currentStreak = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 2, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 2, 9, 5), "Bob", "hey").
+		Add(date(2024, 1, 3, 9, 0), "Bob", "hey").
+		Add(date(2024, 1, 5, 9, 0), "Alice", "back")
+
+	report, err := newTestUsecase(t).Streaks(chat.Chat, StreakOptions{AsOf: date(2024, 1, 6, 0, 0), GraceDays: 1, MinLength: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Longest["overall"]; got.Length != 3 || got.Start != "2024-01-01" {
+		t.Errorf("longest overall = %+v; want 3 days from 2024-01-01", got)
+	}
+	if got := report.Current["Alice"]; got.Length != 1 || got.End != "2024-01-05" {
+		t.Errorf("current Alice = %+v; want the 2024-01-05 day within grace", got)
+	}
+	if got := report.Current["Bob"]; got.Length != 0 {
+		t.Errorf("current Bob = %+v; want broken", got)
+	}
+	want := []domain.Streak{{Start: "2024-01-01", End: "2024-01-02", Length: 2}}
+	if !reflect.DeepEqual(report.Streaks["Alice"], want) {
+		t.Errorf("Alice streaks = %+v; want only those of at least 2 days: %+v", report.Streaks["Alice"], want)
+	}
+}
+
+func TestStreaksInLocation(t *testing.T) {
+	// 23:30 UTC on consecutive days is the next morning in Addis Ababa, so
+	// the last message falls on the asOf day only there.
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 23, 30), "Alice", "hi").
+		Add(date(2024, 1, 2, 23, 30), "Bob", "hey")
+	for i, message := range chat.Messages {
+		sentAt, _ := time.Parse(dateTimeLayout, message.Date)
+		chat.Messages[i].DateUnixtime = strconv.FormatInt(sentAt.Unix(), 10)
+	}
+	addis := time.FixedZone("EAT", 3*60*60)
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		asOf time.Time
+		want domain.Streak
+	}{
+		{"wall clock", nil, date(2024, 1, 3, 0, 0), domain.Streak{}},
+		{"addis ababa", addis, time.Date(2024, 1, 3, 0, 0, 0, 0, addis), domain.Streak{Start: "2024-01-02", End: "2024-01-03", Length: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := newTestUsecase(t).CurrentStreak(chat.Chat, StreakOptions{Location: tt.loc, AsOf: tt.asOf})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := current["overall"]; got != tt.want {
+				t.Errorf("current overall = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
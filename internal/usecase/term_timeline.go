@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"regexp"
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// TermQuery is one term to trace. Plain terms are words or phrases matched
+// on whole words; when Pattern is set it is matched instead of Term.
+type TermQuery struct {
+	Term    string
+	Pattern *regexp.Regexp
+}
+
+// TermTimeline traces when each term was used. Text is normalized exactly
+// like CountWords (split on whitespace, trimmed of .,!" and lowercased), and
+// regular expressions run against that normalized text joined by spaces.
+func (u *messageUsecase) TermTimeline(chat domain.Chat, queries []TermQuery, loc *time.Location) (domain.TermTimeline, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	messages := u.sortedMessages(chat, loc)
+
+	timeline := domain.TermTimeline{Periods: []string{}, Terms: []domain.TermUsage{}}
+	monthIndex := make(map[string]int)
+	if len(messages) > 0 {
+		first := periodStart(messages[0].At, GranularityMonth, time.Monday)
+		last := periodStart(messages[len(messages)-1].At, GranularityMonth, time.Monday)
+		for month := first; !month.After(last); month = nextPeriod(month, GranularityMonth, 1) {
+			key := month.Format(dateLayout)
+			monthIndex[key] = len(timeline.Periods)
+			timeline.Periods = append(timeline.Periods, key)
+		}
+	}
+
+	phrases := make([][]string, len(queries))
+	for i, query := range queries {
+		if query.Pattern == nil {
+			phrases[i] = tokenize(query.Term)
+		}
+		timeline.Terms = append(timeline.Terms, domain.TermUsage{
+			Term:    query.Term,
+			Regex:   query.Pattern != nil,
+			Counts:  map[string]int{personOne: 0, personTwo: 0},
+			Monthly: make([]int, len(timeline.Periods)),
+		})
+	}
+
+	for _, message := range messages {
+		words := tokenize(messageText(message.Message))
+		if len(words) == 0 {
+			continue
+		}
+		normalized := strings.Join(words, " ")
+		month := monthIndex[periodStart(message.At, GranularityMonth, time.Monday).Format(dateLayout)]
+
+		for i, query := range queries {
+			var hits int
+			if query.Pattern != nil {
+				hits = len(query.Pattern.FindAllStringIndex(normalized, -1))
+			} else {
+				hits = countPhrase(words, phrases[i])
+			}
+			if hits == 0 {
+				continue
+			}
+
+			usage := &timeline.Terms[i]
+			usage.Total += hits
+			usage.Counts[message.From] += hits
+			usage.Monthly[month] += hits
+			occurrence := &domain.TermOccurrence{
+				Date:      message.At.Format(dateTimeLayout),
+				From:      message.From,
+				MessageID: message.ID,
+			}
+			if usage.FirstUse == nil {
+				usage.FirstUse = occurrence
+			}
+			usage.LastUse = occurrence
+		}
+	}
+	return timeline, nil
+}
+
+// countPhrase counts non-overlapping occurrences of phrase in words.
+func countPhrase(words, phrase []string) int {
+	if len(phrase) == 0 {
+		return 0
+	}
+	count := 0
+	for i := 0; i+len(phrase) <= len(words); {
+		match := true
+		for j, word := range phrase {
+			if words[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			count++
+			i += len(phrase)
+		} else {
+			i++
+		}
+	}
+	return count
+}
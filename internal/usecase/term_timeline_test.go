@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestCountPhrase(t *testing.T) {
+	tests := []struct {
+		words  string
+		phrase string
+		want   int
+	}{
+		{"love you love you", "love you", 2},
+		{"ha ha ha", "ha ha", 1},
+		{"i love pizza", "love you", 0},
+		{"love", "love you", 0},
+		{"anything", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.words+"/"+tt.phrase, func(t *testing.T) {
+			if got := countPhrase(tokenize(tt.words), tokenize(tt.phrase)); got != tt.want {
+				t.Errorf("countPhrase = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTermTimeline(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 5, 9, 0), "Alice", "Love you!").
+		Add(date(2024, 1, 5, 9, 1), "Bob", "lol love you too").
+		Add(date(2024, 3, 2, 9, 0), "Alice", "loool").
+		Add(date(2024, 3, 2, 9, 1), "Bob", "you love that")
+
+	queries := []TermQuery{
+		{Term: "love you"},
+		{Term: "lo+l", Pattern: regexp.MustCompile(`\blo+l\b`)},
+	}
+	timeline, err := newTestUsecase(t).TermTimeline(chat.Chat, queries, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2024-01-01", "2024-02-01", "2024-03-01"}; !reflect.DeepEqual(timeline.Periods, want) {
+		t.Fatalf("periods = %v; want %v", timeline.Periods, want)
+	}
+
+	tests := []struct {
+		term     string
+		monthly  []int
+		counts   map[string]int
+		firstUse int
+		lastUse  int
+	}{
+		{"love you", []int{2, 0, 0}, map[string]int{"Alice": 1, "Bob": 1}, 1, 2},
+		{"lo+l", []int{1, 0, 1}, map[string]int{"Alice": 1, "Bob": 1}, 2, 3},
+	}
+	for i, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			usage := timeline.Terms[i]
+			if !reflect.DeepEqual(usage.Monthly, tt.monthly) || !reflect.DeepEqual(usage.Counts, tt.counts) {
+				t.Errorf("monthly = %v, counts = %v; want %v and %v", usage.Monthly, usage.Counts, tt.monthly, tt.counts)
+			}
+			if usage.FirstUse == nil || usage.FirstUse.MessageID != tt.firstUse || usage.LastUse.MessageID != tt.lastUse {
+				t.Errorf("first use = %+v, last use = %+v; want messages %d and %d", usage.FirstUse, usage.LastUse, tt.firstUse, tt.lastUse)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"strings"
+	"telegram-chat-analyzer/internal/domain"
+	"unicode"
+)
+
+// messageText flattens a message's text. Telegram exports plain messages as
+// a string, but formatted ones as an array mixing strings and entity objects
+// like {"type": "bold", "text": "..."}.
+func messageText(message domain.Message) string {
+	switch text := message.Text.(type) {
+	case string:
+		return text
+	case []interface{}:
+		var builder strings.Builder
+		for _, part := range text {
+			switch part := part.(type) {
+			case string:
+				builder.WriteString(part)
+			case map[string]interface{}:
+				if s, ok := part["text"].(string); ok {
+					builder.WriteString(s)
+				}
+			}
+		}
+		return builder.String()
+	}
+	return ""
+}
+
+// messageEntities returns the message's formatting entities. Older exports
+// and hand-built chats may only carry them inside the text array, so fall
+// back to the entity objects found there.
+func messageEntities(message domain.Message) []domain.TextEntity {
+	if len(message.TextEntities) > 0 {
+		return message.TextEntities
+	}
+	parts, ok := message.Text.([]interface{})
+	if !ok {
+		return nil
+	}
+	var entities []domain.TextEntity
+	for _, part := range parts {
+		object, ok := part.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entity := domain.TextEntity{}
+		entity.Type, _ = object["type"].(string)
+		entity.Text, _ = object["text"].(string)
+		entity.Href, _ = object["href"].(string)
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// isMedia reports whether a message carries a photo, file, sticker, voice
+// note or other attachment.
+func isMedia(message domain.Message) bool {
+	return message.Photo != "" || message.File != "" || message.MediaType != ""
+}
+
+// isServiceMessage reports whether a message is a Telegram service entry
+// (calls, pins, joins) rather than something a participant wrote.
+func isServiceMessage(message domain.Message) bool {
+	return message.Type == "service"
+}
+
+// normalizeWord applies the same cleanup CountWords has always used.
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.Trim(word, ".,!\""))
+}
+
+// tokenize splits text on whitespace and normalizes each word, dropping
+// anything that is empty after cleanup.
+func tokenize(text string) []string {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if cleaned := normalizeWord(word); cleaned != "" {
+			words = append(words, cleaned)
+		}
+	}
+	return words
+}
+
+// isEmojiRune reports whether r is a pictographic emoji. Skin tone
+// modifiers are excluded so they do not count as separate emoji.
+func isEmojiRune(r rune) bool {
+	if r >= 0x1F3FB && r <= 0x1F3FF {
+		return false
+	}
+	return (r >= 0x1F300 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) || unicode.Is(unicode.So, r)
+}
+
+// wordsAndEmoji lowercases text and splits it into words (letters, digits and
+// apostrophes) and single-emoji tokens, dropping everything else.
+func wordsAndEmoji(text string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == 0xFE0F: // emoji presentation selector, e.g. the tail of ❤️
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) || r == '\'' || r == '’':
+			word.WriteRune(r)
+		case isEmojiRune(r):
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// wordTokens is wordsAndEmoji without the emoji.
+func wordTokens(text string) []string {
+	var words []string
+	for _, token := range wordsAndEmoji(text) {
+		if r := []rune(token); len(r) == 1 && isEmojiRune(r[0]) {
+			continue
+		}
+		words = append(words, token)
+	}
+	return words
+}
+
+// ngrams joins every run of n consecutive tokens with a single space.
+func ngrams(tokens []string, n int) []string {
+	if n < 1 || len(tokens) < n {
+		return nil
+	}
+	grams := make([]string, 0, len(tokens)-n+1)
+	for i := 0; i+n <= len(tokens); i++ {
+		grams = append(grams, strings.Join(tokens[i:i+n], " "))
+	}
+	return grams
+}
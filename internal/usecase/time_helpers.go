@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02T15:04:05"
+	minutesPerDay  = 24 * 60
+)
+
+// messageTime returns when a message was sent. With a nil location the
+// wall clock stored in the export is used as-is, which is what the older
+// endpoints do. With a location the unix timestamp is converted into it so
+// day and hour buckets follow the requested timezone.
+func messageTime(message domain.Message, loc *time.Location) (time.Time, error) {
+	if loc != nil && message.DateUnixtime != "" {
+		seconds, err := strconv.ParseInt(message.DateUnixtime, 10, 64)
+		if err == nil {
+			return time.Unix(seconds, 0).In(loc), nil
+		}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.ParseInLocation(dateTimeLayout, message.Date, loc)
+}
+
+// dayNumber maps a time to the index of its calendar day, so that adjacent
+// days always differ by exactly one regardless of DST shifts.
+func dayNumber(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// dayToDate formats a day index produced by dayNumber as 2006-01-02.
+func dayToDate(day int) string {
+	return time.Unix(int64(day)*86400, 0).UTC().Format(dateLayout)
+}
+
+// Granularities accepted by periodStart and nextPeriod.
+const (
+	GranularityDay     = "day"
+	GranularityWeek    = "week"
+	GranularityMonth   = "month"
+	GranularityQuarter = "quarter"
+	GranularityYear    = "year"
+)
+
+func validGranularity(granularity string) bool {
+	switch granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth, GranularityQuarter, GranularityYear:
+		return true
+	}
+	return false
+}
+
+// periodStart truncates t to the first instant of its day, week, month,
+// quarter or year in t's location. Weeks begin on weekStart.
+func periodStart(t time.Time, granularity string, weekStart time.Weekday) time.Time {
+	year, month, day := t.Date()
+	switch granularity {
+	case GranularityWeek:
+		offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case GranularityMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case GranularityQuarter:
+		firstMonth := time.Month((int(month)-1)/3*3 + 1)
+		return time.Date(year, firstMonth, 1, 0, 0, 0, 0, t.Location())
+	case GranularityYear:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// nextPeriod moves a period start forward by n periods.
+func nextPeriod(start time.Time, granularity string, n int) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return start.AddDate(0, 0, 7*n)
+	case GranularityMonth:
+		return start.AddDate(0, n, 0)
+	case GranularityQuarter:
+		return start.AddDate(0, 3*n, 0)
+	case GranularityYear:
+		return start.AddDate(n, 0, 0)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
+
+// chatBetween returns a copy of chat holding only messages sent in
+// [from, to), keeping their original order.
+func chatBetween(chat domain.Chat, loc *time.Location, from, to time.Time) domain.Chat {
+	filtered := chat
+	filtered.Messages = nil
+	for _, message := range chat.Messages {
+		sentAt, err := messageTime(message, loc)
+		if err != nil || sentAt.Before(from) || !sentAt.Before(to) {
+			continue
+		}
+		filtered.Messages = append(filtered.Messages, message)
+	}
+	return filtered
+}
+
+// chatInLocation returns a copy of chat whose Date fields hold the send times
+// in loc, so the analyses that read the export's wall clock directly see the
+// requested timezone. A nil loc returns chat unchanged.
+func chatInLocation(chat domain.Chat, loc *time.Location) domain.Chat {
+	if loc == nil {
+		return chat
+	}
+	shifted := chat
+	shifted.Messages = make([]domain.Message, len(chat.Messages))
+	for i, message := range chat.Messages {
+		if sentAt, err := messageTime(message, loc); err == nil {
+			message.Date = sentAt.Format(dateTimeLayout)
+		}
+		shifted.Messages[i] = message
+	}
+	return shifted
+}
+
+// chatTimeRange returns the earliest and latest parseable message times.
+func chatTimeRange(chat domain.Chat, loc *time.Location) (time.Time, time.Time, bool) {
+	var first, last time.Time
+	found := false
+	for _, message := range chat.Messages {
+		sentAt, err := messageTime(message, loc)
+		if err != nil {
+			continue
+		}
+		if !found || sentAt.Before(first) {
+			first = sentAt
+		}
+		if !found || sentAt.After(last) {
+			last = sentAt
+		}
+		found = true
+	}
+	return first, last, found
+}
+
+// minuteOfDay is the number of minutes since midnight on t's wall clock.
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// formatMinuteOfDay writes a minute of the day as 15:04, wrapping values
+// outside [0, 24h).
+func formatMinuteOfDay(minute int) string {
+	minute = circularMinutes(minute)
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// circularMinutes wraps minutes into [0, 24h).
+func circularMinutes(minutes int) int {
+	return ((minutes % minutesPerDay) + minutesPerDay) % minutesPerDay
+}
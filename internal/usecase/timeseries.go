@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"fmt"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// Metrics reported by TimeSeries.
+const (
+	MetricMessages   = "messages"
+	MetricWords      = "words"
+	MetricCharacters = "characters"
+	MetricMedia      = "media"
+)
+
+// SeriesOptions controls how TimeSeries buckets and post-processes counts.
+type SeriesOptions struct {
+	// Location is the timezone periods are aligned to. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Granularity is day, week, month or year.
+	Granularity string
+	// WeekStart is the first day of a week when Granularity is week.
+	WeekStart time.Weekday
+	// RollingWindow adds a trailing average over this many periods when above 1.
+	RollingWindow int
+	// Cumulative adds running totals.
+	Cumulative bool
+}
+
+func (u *messageUsecase) TimeSeries(chat domain.Chat, opts SeriesOptions) (domain.TimeSeries, error) {
+	switch opts.Granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth, GranularityYear:
+	default:
+		return domain.TimeSeries{}, fmt.Errorf("granularity must be day, week, month or year")
+	}
+
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo, "overall"}
+	metrics := []string{MetricMessages, MetricWords, MetricCharacters, MetricMedia}
+
+	result := domain.TimeSeries{
+		Granularity: opts.Granularity,
+		Periods:     []string{},
+		Series:      make(map[string]map[string]domain.Series),
+	}
+
+	first, last, ok := chatTimeRange(chat, opts.Location)
+	if !ok {
+		return result, nil
+	}
+
+	// Lay out every period between the first and last message so that
+	// quiet periods show up as zeros instead of being skipped.
+	index := make(map[string]int)
+	lastPeriod := periodStart(last, opts.Granularity, opts.WeekStart)
+	for period := periodStart(first, opts.Granularity, opts.WeekStart); !period.After(lastPeriod); period = nextPeriod(period, opts.Granularity, 1) {
+		key := period.Format(dateLayout)
+		index[key] = len(result.Periods)
+		result.Periods = append(result.Periods, key)
+	}
+
+	counts := make(map[string]map[string][]int)
+	for _, metric := range metrics {
+		counts[metric] = make(map[string][]int)
+		for _, participant := range participants {
+			counts[metric][participant] = make([]int, len(result.Periods))
+		}
+	}
+
+	for _, message := range chat.Messages {
+		if isServiceMessage(message) || (message.From != personOne && message.From != personTwo) {
+			continue
+		}
+		sentAt, err := messageTime(message, opts.Location)
+		if err != nil {
+			continue
+		}
+		i := index[periodStart(sentAt, opts.Granularity, opts.WeekStart).Format(dateLayout)]
+
+		text := messageText(message)
+		values := map[string]int{
+			MetricMessages:   1,
+			MetricWords:      len(tokenize(text)),
+			MetricCharacters: utf8.RuneCountInString(text),
+		}
+		if isMedia(message) {
+			values[MetricMedia] = 1
+		}
+		for metric, value := range values {
+			counts[metric][message.From][i] += value
+			counts[metric]["overall"][i] += value
+		}
+	}
+
+	for metric, byParticipant := range counts {
+		result.Series[metric] = make(map[string]domain.Series)
+		for participant, values := range byParticipant {
+			series := domain.Series{Values: values}
+			if opts.RollingWindow > 1 {
+				series.RollingAverage = rollingAverage(values, opts.RollingWindow)
+			}
+			if opts.Cumulative {
+				series.Cumulative = cumulativeSum(values)
+			}
+			result.Series[metric][participant] = series
+		}
+	}
+	return result, nil
+}
+
+// rollingAverage returns the trailing mean over window values. The first
+// window-1 points average over however many values are available.
+func rollingAverage(values []int, window int) []float64 {
+	averages := make([]float64, len(values))
+	sum := 0
+	for i, value := range values {
+		sum += value
+		if i >= window {
+			sum -= values[i-window]
+		}
+		size := window
+		if i+1 < window {
+			size = i + 1
+		}
+		averages[i] = roundTo(float64(sum)/float64(size), 2)
+	}
+	return averages
+}
+
+func cumulativeSum(values []int) []int {
+	totals := make([]int, len(values))
+	running := 0
+	for i, value := range values {
+		running += value
+		totals[i] = running
+	}
+	return totals
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-chat-analyzer/internal/domain"
+)
+
+func TestTimeSeriesFillsGaps(t *testing.T) {
+	// The call at the end has no sender and must not hide either participant.
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 1, 9, 5), "Bob", "hey").
+		Add(date(2024, 1, 4, 22, 0), "Alice", "back").
+		Service(date(2024, 1, 4, 22, 30), "phone_call", 300)
+
+	series, err := newTestUsecase(t).TimeSeries(chat.Chat, SeriesOptions{Granularity: GranularityDay, RollingWindow: 2, Cumulative: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04"}; !reflect.DeepEqual(series.Periods, want) {
+		t.Fatalf("periods = %v; want %v", series.Periods, want)
+	}
+	messages := series.Series[MetricMessages]
+	tests := []struct {
+		participant string
+		want        domain.Series
+	}{
+		{"Alice", domain.Series{Values: []int{1, 0, 0, 1}, RollingAverage: []float64{1, 0.5, 0, 0.5}, Cumulative: []int{1, 1, 1, 2}}},
+		{"Bob", domain.Series{Values: []int{1, 0, 0, 0}, RollingAverage: []float64{1, 0.5, 0, 0}, Cumulative: []int{1, 1, 1, 1}}},
+		{"overall", domain.Series{Values: []int{2, 0, 0, 1}, RollingAverage: []float64{2, 1, 0, 0.5}, Cumulative: []int{2, 2, 2, 3}}},
+	}
+	for _, tt := range tests {
+		if got := messages[tt.participant]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %+v; want %+v", tt.participant, got, tt.want)
+		}
+	}
+	if len(messages) != len(tests) {
+		t.Errorf("participants = %v; want Alice, Bob and overall", messages)
+	}
+}
+
+func TestTimeSeriesWeekStart(t *testing.T) {
+	// 2024-01-06 is a Saturday and 2024-01-14 a Sunday.
+	chat := newTestChat().
+		Add(date(2024, 1, 6, 12, 0), "Alice", "saturday").
+		Add(date(2024, 1, 14, 12, 0), "Bob", "sunday")
+
+	tests := []struct {
+		weekStart time.Weekday
+		periods   []string
+		values    []int
+	}{
+		{time.Sunday, []string{"2023-12-31", "2024-01-07", "2024-01-14"}, []int{1, 0, 1}},
+		{time.Monday, []string{"2024-01-01", "2024-01-08"}, []int{1, 1}},
+		{time.Saturday, []string{"2024-01-06", "2024-01-13"}, []int{1, 1}},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.weekStart.String(), func(t *testing.T) {
+			series, err := u.TimeSeries(chat.Chat, SeriesOptions{Granularity: GranularityWeek, WeekStart: tt.weekStart})
+			if err != nil {
+				t.Fatal(err)
+			}
+			values := series.Series[MetricMessages]["overall"].Values
+			if !reflect.DeepEqual(series.Periods, tt.periods) || !reflect.DeepEqual(values, tt.values) {
+				t.Errorf("periods %v, values %v; want %v and %v", series.Periods, values, tt.periods, tt.values)
+			}
+		})
+	}
+}
+
+func TestTimeSeriesMetrics(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "Hello, wörld!")
+	chat.Messages = append(chat.Messages,
+		domain.Message{ID: 2, Type: "message", Date: "2024-01-01T09:01:00", From: "Bob", FromID: "user2",
+			Text: []interface{}{"look ", map[string]interface{}{"type": "bold", "text": "here"}}, Photo: "photos/1.jpg"},
+		domain.Message{ID: 3, Type: "message", Date: "2024-01-01T09:02:00", From: "Bob", FromID: "user2",
+			Text: "", MediaType: "sticker", StickerEmoji: "😀"},
+	)
+
+	series, err := newTestUsecase(t).TimeSeries(chat.Chat, SeriesOptions{Granularity: GranularityMonth})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		metric      string
+		participant string
+		want        int
+	}{
+		{MetricMessages, "Bob", 2},
+		{MetricWords, "Alice", 2},
+		{MetricWords, "Bob", 2},
+		{MetricCharacters, "Alice", 13},
+		{MetricCharacters, "Bob", 9},
+		{MetricMedia, "Alice", 0},
+		{MetricMedia, "Bob", 2},
+		{MetricMedia, "overall", 2},
+	}
+	for _, tt := range tests {
+		if got := series.Series[tt.metric][tt.participant].Values; !reflect.DeepEqual(got, []int{tt.want}) {
+			t.Errorf("%s for %s = %v; want [%d]", tt.metric, tt.participant, got, tt.want)
+		}
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		window int
+		want   []float64
+	}{
+		{"partial first windows", []int{1, 2, 3, 4}, 3, []float64{1, 1.5, 2, 3}},
+		{"window longer than values", []int{2, 4}, 5, []float64{2, 3}},
+		{"window of one", []int{5, 0, 1}, 1, []float64{5, 0, 1}},
+		{"rounded", []int{1, 0, 0}, 3, []float64{1, 0.5, 0.33}},
+		{"empty", []int{}, 3, []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rollingAverage(tt.values, tt.window); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rollingAverage(%v, %d) = %v; want %v", tt.values, tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCumulativeSum(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   []int
+	}{
+		{[]int{1, 0, 2, 3}, []int{1, 1, 3, 6}},
+		{[]int{0, 0}, []int{0, 0}},
+		{[]int{}, []int{}},
+	}
+	for _, tt := range tests {
+		if got := cumulativeSum(tt.values); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("cumulativeSum(%v) = %v; want %v", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Topic model document units.
+const (
+	TopicUnitSession = "session"
+	TopicUnitDay     = "day"
+)
+
+// TopicOptions controls the LDA topic model.
+type TopicOptions struct {
+	// Location is the timezone used for days and months. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Unit is session or day and decides what counts as one document.
+	Unit string
+	// SessionGap splits sessions when Unit is session.
+	SessionGap time.Duration
+	// Topics is the number of topics to find.
+	Topics int
+	// Iterations is the number of Gibbs sampling sweeps. Fewer are run when
+	// the chat is too large to afford them, see maxTopicSamplingWork.
+	Iterations int
+	// TermsPerTopic is how many top terms to return for each topic.
+	TermsPerTopic int
+}
+
+const (
+	// ldaAlpha and ldaBeta are the usual sparse Dirichlet priors: documents
+	// mix few topics and topics use few words.
+	ldaAlpha = 0.1
+	ldaBeta  = 0.01
+	// ldaSeed keeps results stable between requests for the same chat.
+	ldaSeed = 1
+	// maxTopicSamplingWork bounds iterations × words × topics, the number of
+	// topic weights one request computes, to about a second of CPU.
+	maxTopicSamplingWork = 50_000_000
+	// maxDocumentShare drops terms found in more than this share of documents;
+	// they behave like stopwords in any language.
+	maxDocumentShare = 0.5
+)
+
+var englishStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "do": true, "for": true, "from": true, "have": true, "he": true, "her": true, "him": true,
+	"i": true, "i'm": true, "if": true, "in": true, "is": true, "it": true, "it's": true, "me": true,
+	"my": true, "no": true, "not": true, "of": true, "on": true, "or": true, "so": true, "that": true,
+	"the": true, "then": true, "there": true, "they": true, "this": true, "to": true, "u": true, "was": true,
+	"we": true, "what": true, "with": true, "you": true, "your": true, "yes": true, "ok": true, "okay": true,
+}
+
+// topicDocument is one session or day reduced to vocabulary ids, with the
+// participant index that wrote each word.
+type topicDocument struct {
+	start    time.Time
+	words    []int
+	speakers []int
+}
+
+// Topics fits an LDA topic model with collapsed Gibbs sampling over the
+// chat's sessions or days, then reports each topic's top terms, how its
+// share changes month by month, and which participant brings it up.
+func (u *messageUsecase) Topics(chat domain.Chat, opts TopicOptions) (domain.TopicReport, error) {
+	if opts.Topics < 1 {
+		return domain.TopicReport{}, fmt.Errorf("number of topics must be at least 1")
+	}
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	messages := u.sortedMessages(chat, opts.Location)
+	var groups [][]timedMessage
+	if opts.Unit == TopicUnitDay {
+		for i, message := range messages {
+			if i == 0 || dayNumber(message.At) != dayNumber(messages[i-1].At) {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], message)
+		}
+	} else {
+		for _, s := range splitSessions(messages, opts.SessionGap) {
+			groups = append(groups, s.Messages)
+		}
+	}
+
+	vocabulary, documents := buildTopicDocuments(groups, personOne)
+	report := domain.TopicReport{
+		Unit:      opts.Unit,
+		Documents: len(documents),
+		Periods:   []string{},
+		Topics:    []domain.Topic{},
+	}
+	if len(documents) == 0 {
+		return report, nil
+	}
+
+	k, v := opts.Topics, len(vocabulary)
+	words := 0
+	for _, doc := range documents {
+		words += len(doc.words)
+	}
+	report.Iterations = opts.Iterations
+	if limit := maxTopicSamplingWork / (words * k); report.Iterations > limit {
+		report.Iterations = limit
+		if report.Iterations < 1 {
+			report.Iterations = 1
+		}
+	}
+
+	docTopic := make([][]int, len(documents))
+	topicWord := make([][]int, k)
+	topicTotal := make([]int, k)
+	for topic := range topicWord {
+		topicWord[topic] = make([]int, v)
+	}
+
+	rng := rand.New(rand.NewSource(ldaSeed))
+	assignments := make([][]int, len(documents))
+	for d, doc := range documents {
+		docTopic[d] = make([]int, k)
+		assignments[d] = make([]int, len(doc.words))
+		for i, word := range doc.words {
+			topic := rng.Intn(k)
+			assignments[d][i] = topic
+			docTopic[d][topic]++
+			topicWord[topic][word]++
+			topicTotal[topic]++
+		}
+	}
+
+	weights := make([]float64, k)
+	vocabularyPrior := float64(v) * ldaBeta
+	for iteration := 0; iteration < report.Iterations; iteration++ {
+		for d, doc := range documents {
+			for i, word := range doc.words {
+				topic := assignments[d][i]
+				docTopic[d][topic]--
+				topicWord[topic][word]--
+				topicTotal[topic]--
+
+				sum := 0.0
+				for t := 0; t < k; t++ {
+					sum += (float64(docTopic[d][t]) + ldaAlpha) *
+						(float64(topicWord[t][word]) + ldaBeta) /
+						(float64(topicTotal[t]) + vocabularyPrior)
+					weights[t] = sum
+				}
+				draw := rng.Float64() * sum
+				topic = sort.SearchFloat64s(weights, draw)
+				if topic >= k {
+					topic = k - 1
+				}
+
+				assignments[d][i] = topic
+				docTopic[d][topic]++
+				topicWord[topic][word]++
+				topicTotal[topic]++
+			}
+		}
+	}
+
+	// Monthly timeline of the average topic share per document.
+	first := periodStart(documents[0].start, GranularityMonth, time.Monday)
+	last := periodStart(documents[len(documents)-1].start, GranularityMonth, time.Monday)
+	monthIndex := make(map[string]int)
+	for month := first; !month.After(last); month = nextPeriod(month, GranularityMonth, 1) {
+		key := month.Format(dateLayout)
+		monthIndex[key] = len(report.Periods)
+		report.Periods = append(report.Periods, key)
+	}
+	monthShares := make([][]float64, k)
+	for topic := range monthShares {
+		monthShares[topic] = make([]float64, len(report.Periods))
+	}
+	monthDocuments := make([]int, len(report.Periods))
+	for d, doc := range documents {
+		m := monthIndex[periodStart(doc.start, GranularityMonth, time.Monday).Format(dateLayout)]
+		monthDocuments[m]++
+		for topic := 0; topic < k; topic++ {
+			monthShares[topic][m] += (float64(docTopic[d][topic]) + ldaAlpha) / (float64(len(doc.words)) + float64(k)*ldaAlpha)
+		}
+	}
+
+	totalWords := 0
+	speakerCounts := make([][2]int, k)
+	for d, doc := range documents {
+		totalWords += len(doc.words)
+		for i, topic := range assignments[d] {
+			speakerCounts[topic][doc.speakers[i]]++
+		}
+	}
+
+	for topic := 0; topic < k; topic++ {
+		if topicTotal[topic] == 0 {
+			continue
+		}
+		terms := make([]domain.TopicTerm, 0, v)
+		for word, count := range topicWord[topic] {
+			if count == 0 {
+				continue
+			}
+			terms = append(terms, domain.TopicTerm{
+				Term:   vocabulary[word],
+				Weight: roundTo((float64(count)+ldaBeta)/(float64(topicTotal[topic])+vocabularyPrior), 4),
+			})
+		}
+		sort.Slice(terms, func(i, j int) bool {
+			if terms[i].Weight != terms[j].Weight {
+				return terms[i].Weight > terms[j].Weight
+			}
+			return terms[i].Term < terms[j].Term
+		})
+		if len(terms) > opts.TermsPerTopic {
+			terms = terms[:opts.TermsPerTopic]
+		}
+
+		timeline := make([]float64, len(report.Periods))
+		for m, share := range monthShares[topic] {
+			if monthDocuments[m] > 0 {
+				timeline[m] = roundTo(share/float64(monthDocuments[m]), 4)
+			}
+		}
+
+		drivers := make(map[string]float64, len(participants))
+		for i, person := range participants {
+			drivers[person] = roundTo(float64(speakerCounts[topic][i])/float64(topicTotal[topic]), 4)
+		}
+
+		report.Topics = append(report.Topics, domain.Topic{
+			Terms:      terms,
+			Prevalence: roundTo(float64(topicTotal[topic])/float64(totalWords), 4),
+			Drivers:    drivers,
+			Timeline:   timeline,
+		})
+	}
+
+	sort.SliceStable(report.Topics, func(i, j int) bool {
+		return report.Topics[i].Prevalence > report.Topics[j].Prevalence
+	})
+	for i := range report.Topics {
+		report.Topics[i].ID = i + 1
+	}
+	return report, nil
+}
+
+// buildTopicDocuments tokenizes each message group and keeps terms that are
+// neither stopwords, numbers, single letters, one-offs nor present in most
+// documents. Documents left with no terms are dropped.
+func buildTopicDocuments(groups [][]timedMessage, personOne string) ([]string, []topicDocument) {
+	type rawDocument struct {
+		start    time.Time
+		terms    []string
+		speakers []int
+	}
+
+	raw := make([]rawDocument, 0, len(groups))
+	documentFrequency := make(map[string]int)
+	for _, group := range groups {
+		doc := rawDocument{start: group[0].At}
+		seen := make(map[string]bool)
+		for _, message := range group {
+			speaker := 1
+			if message.From == personOne {
+				speaker = 0
+			}
+			for _, term := range wordTokens(messageText(message.Message)) {
+				if englishStopwords[term] || utf8.RuneCountInString(term) < 2 || isNumeric(term) {
+					continue
+				}
+				doc.terms = append(doc.terms, term)
+				doc.speakers = append(doc.speakers, speaker)
+				if !seen[term] {
+					seen[term] = true
+					documentFrequency[term]++
+				}
+			}
+		}
+		raw = append(raw, doc)
+	}
+
+	maxDocuments := int(maxDocumentShare * float64(len(raw)))
+	var vocabulary []string
+	for term, frequency := range documentFrequency {
+		if frequency >= 2 && (frequency <= maxDocuments || len(raw) < 4) {
+			vocabulary = append(vocabulary, term)
+		}
+	}
+	sort.Strings(vocabulary)
+	ids := make(map[string]int, len(vocabulary))
+	for id, term := range vocabulary {
+		ids[term] = id
+	}
+
+	var documents []topicDocument
+	for _, doc := range raw {
+		document := topicDocument{start: doc.start}
+		for i, term := range doc.terms {
+			if id, ok := ids[term]; ok {
+				document.words = append(document.words, id)
+				document.speakers = append(document.speakers, doc.speakers[i])
+			}
+		}
+		if len(document.words) > 0 {
+			documents = append(documents, document)
+		}
+	}
+	return vocabulary, documents
+}
+
+func isNumeric(term string) bool {
+	for _, r := range term {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
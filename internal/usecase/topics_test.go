@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTopicsSeparatesThemes(t *testing.T) {
+	chat := newTestChat()
+	food := []string{"pizza pasta cheese", "pasta cheese sauce", "pizza sauce oven"}
+	sport := []string{"football match goal", "goal referee match", "football referee stadium"}
+	for day := 1; day <= 8; day++ {
+		lines := food
+		if day%2 == 0 {
+			lines = sport
+		}
+		at := date(2024, 1, day, 18, 0)
+		for i, line := range lines {
+			from := "Alice"
+			if i%2 == 1 {
+				from = "Bob"
+			}
+			chat.Add(at.Add(time.Duration(i)*time.Minute), from, line)
+		}
+	}
+
+	uc := newTestUsecase(t)
+	opts := TopicOptions{Unit: TopicUnitSession, SessionGap: time.Hour, Topics: 2, Iterations: 100, TermsPerTopic: 3}
+	report, err := uc.Topics(chat.Chat, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Documents != 8 || report.Iterations != 100 || len(report.Topics) != 2 {
+		t.Fatalf("report = %+v; want 8 documents, 100 iterations and 2 topics", report)
+	}
+
+	themes := map[string]string{}
+	for _, line := range food {
+		for _, word := range wordTokens(line) {
+			themes[word] = "food"
+		}
+	}
+	for _, line := range sport {
+		for _, word := range wordTokens(line) {
+			themes[word] = "sport"
+		}
+	}
+	seen := map[string]bool{}
+	for _, topic := range report.Topics {
+		theme := themes[topic.Terms[0].Term]
+		for _, term := range topic.Terms {
+			if themes[term.Term] != theme {
+				t.Errorf("topic %d mixes themes: %+v", topic.ID, topic.Terms)
+				break
+			}
+		}
+		seen[theme] = true
+	}
+	if !seen["food"] || !seen["sport"] {
+		t.Errorf("topics = %+v; want one food and one sport topic", report.Topics)
+	}
+
+	// The sampler is seeded, so the same request gives the same topics.
+	again, err := uc.Topics(chat.Chat, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report, again) {
+		t.Errorf("second run differs:\n%+v\n%+v", report, again)
+	}
+}
+
+func TestBuildTopicDocuments(t *testing.T) {
+	groups := [][]timedMessage{
+		{{At: date(2024, 1, 1, 9, 0)}},
+		{{At: date(2024, 1, 2, 9, 0)}},
+	}
+	groups[0][0].From, groups[0][0].Text = "Alice", "the pizza 42 x pizza unique"
+	groups[1][0].From, groups[1][0].Text = "Bob", "pizza again"
+
+	vocabulary, documents := buildTopicDocuments(groups, "Alice")
+	if !reflect.DeepEqual(vocabulary, []string{"pizza"}) {
+		t.Errorf("vocabulary = %v; want only pizza", vocabulary)
+	}
+	if len(documents) != 2 || !reflect.DeepEqual(documents[0].speakers, []int{0, 0}) || !reflect.DeepEqual(documents[1].speakers, []int{1}) {
+		t.Errorf("documents = %+v; want pizza twice from Alice and once from Bob", documents)
+	}
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// DefaultOverlapWindow is how quickly both sides must write for a reply to
+// count as cutting into a burst that was still going on.
+const DefaultOverlapWindow = 30 * time.Second
+
+// TurnOptions controls TurnTaking.
+type TurnOptions struct {
+	// Location is the timezone used for sessions and months. Nil keeps the export's wall clock.
+	Location *time.Location
+	// SessionGap splits the chat into sessions.
+	SessionGap time.Duration
+	// OverlapWindow decides interruptions, see TurnTaking.
+	OverlapWindow time.Duration
+}
+
+// TurnTaking analyses how the conversation passes back and forth: turns per
+// session, the length of each participant's turns, interruptions and a
+// monthly balance index. A reply interrupts when it arrives within
+// OverlapWindow of the other person's message and that person carries on
+// within OverlapWindow of the reply, i.e. they were still mid-burst.
+func (u *messageUsecase) TurnTaking(chat domain.Chat, opts TurnOptions) (domain.TurnTakingReport, error) {
+	_, personOne, personTwo := u.SeparateMessagesByPerson(chat)
+	participants := []string{personOne, personTwo}
+
+	report := domain.TurnTakingReport{
+		People:  make(map[string]domain.TurnStats, len(participants)),
+		Balance: []domain.BalancePoint{},
+	}
+	messageCounts := map[string][]float64{personOne: {}, personTwo: {}}
+	wordCounts := map[string][]float64{personOne: {}, personTwo: {}}
+	interruptions := make(map[string]int, len(participants))
+	var turnsPerSession []float64
+
+	months := make(map[string]*domain.BalancePoint)
+	var monthOrder []string
+
+	sessions := splitSessions(u.sortedMessages(chat, opts.Location), opts.SessionGap)
+	for _, s := range sessions {
+		turns := splitTurns(s.Messages, opts.SessionGap)
+		turnsPerSession = append(turnsPerSession, float64(len(turns)))
+
+		for i, t := range turns {
+			words := 0
+			for _, message := range t.Messages {
+				words += len(wordTokens(messageText(message.Message)))
+			}
+			messageCounts[t.From] = append(messageCounts[t.From], float64(len(t.Messages)))
+			wordCounts[t.From] = append(wordCounts[t.From], float64(words))
+
+			if i > 0 && i+1 < len(turns) {
+				previous, next := turns[i-1], turns[i+1]
+				if t.Start().Sub(previous.End()) <= opts.OverlapWindow && next.Start().Sub(t.Start()) <= opts.OverlapWindow {
+					interruptions[t.From]++
+				}
+			}
+
+			month := periodStart(t.Start(), GranularityMonth, time.Monday).Format(dateLayout)
+			point := months[month]
+			if point == nil {
+				point = &domain.BalancePoint{
+					Period: month,
+					Turns:  map[string]int{personOne: 0, personTwo: 0},
+					Words:  map[string]int{personOne: 0, personTwo: 0},
+				}
+				months[month] = point
+				monthOrder = append(monthOrder, month)
+			}
+			point.Turns[t.From]++
+			point.Words[t.From] += words
+		}
+	}
+
+	report.Sessions = len(sessions)
+	report.TurnsPerSession = summarize(turnsPerSession)
+	for _, person := range participants {
+		report.People[person] = domain.TurnStats{
+			Turns:         len(messageCounts[person]),
+			Messages:      summarize(messageCounts[person]),
+			Words:         summarize(wordCounts[person]),
+			Interruptions: interruptions[person],
+		}
+	}
+	for _, month := range monthOrder {
+		point := months[month]
+		point.Index = roundTo(1-imbalance(float64(point.Words[personOne]), float64(point.Words[personTwo])), 4)
+		report.Balance = append(report.Balance, *point)
+	}
+	return report, nil
+}
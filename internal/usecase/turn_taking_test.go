@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTurnTakingInterruptions(t *testing.T) {
+	start := date(2024, 1, 1, 10, 0)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name string
+		chat *testChat
+		bob  int
+	}{
+		{
+			name: "reply cuts into a burst",
+			chat: newTestChat().
+				Add(at(0), "Alice", "so anyway").
+				Add(at(10), "Bob", "wait").
+				Add(at(20), "Alice", "let me finish"),
+			bob: 1,
+		},
+		{
+			name: "reply after a pause",
+			chat: newTestChat().
+				Add(at(0), "Alice", "so anyway").
+				Add(at(120), "Bob", "wait").
+				Add(at(130), "Alice", "let me finish"),
+			bob: 0,
+		},
+		{
+			name: "other person stops after the reply",
+			chat: newTestChat().
+				Add(at(0), "Alice", "so anyway").
+				Add(at(10), "Bob", "wait").
+				Add(at(60), "Alice", "ok go on"),
+			bob: 0,
+		},
+		{
+			name: "last turn of the session",
+			chat: newTestChat().
+				Add(at(0), "Alice", "so anyway").
+				Add(at(10), "Bob", "wait"),
+			bob: 0,
+		},
+	}
+	u := newTestUsecase(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := u.TurnTaking(tt.chat.Chat, TurnOptions{SessionGap: time.Hour, OverlapWindow: DefaultOverlapWindow})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := report.People["Bob"].Interruptions; got != tt.bob {
+				t.Errorf("Bob's interruptions = %d; want %d", got, tt.bob)
+			}
+			if got := report.People["Alice"].Interruptions; got != 0 {
+				t.Errorf("Alice's interruptions = %d; want 0", got)
+			}
+		})
+	}
+}
+
+func TestTurnTakingBalance(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 10, 0), "Alice", "one two").
+		Add(date(2024, 1, 1, 10, 1), "Alice", "three").
+		Add(date(2024, 1, 1, 10, 5), "Bob", "four").
+		Add(date(2024, 1, 2, 10, 0), "Bob", "five six")
+
+	report, err := newTestUsecase(t).TurnTaking(chat.Chat, TurnOptions{SessionGap: time.Hour, OverlapWindow: DefaultOverlapWindow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Sessions != 2 || report.TurnsPerSession.Max != 2 || report.TurnsPerSession.Min != 1 {
+		t.Errorf("sessions = %d, turns per session = %+v; want 2 sessions of 2 and 1 turns", report.Sessions, report.TurnsPerSession)
+	}
+	if alice := report.People["Alice"]; alice.Turns != 1 || alice.Messages.Max != 2 || alice.Words.Max != 3 {
+		t.Errorf("Alice = %+v; want 1 turn of 2 messages and 3 words", alice)
+	}
+	if len(report.Balance) != 1 || report.Balance[0].Index != 1 {
+		t.Errorf("balance = %+v; want one month with an index of 1", report.Balance)
+	}
+	if got := report.Balance[0].Turns; got["Alice"] != 1 || got["Bob"] != 2 {
+		t.Errorf("turns = %v; want Alice 1, Bob 2", got)
+	}
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDistinctiveVocabulary(t *testing.T) {
+	chat := newTestChat()
+	for day := 1; day <= 20; day++ {
+		chat.Add(date(2024, 1, day, 9, 0), "Alice", "hello pizza pizza tonight").
+			Add(date(2024, 1, day, 9, 5), "Bob", "hello sushi sushi tonight")
+	}
+	chat.Add(date(2024, 1, 21, 9, 0), "Alice", "weekend plans").
+		Add(date(2024, 1, 21, 9, 5), "Bob", "weekend plans")
+
+	report, err := newTestUsecase(t).DistinctiveVocabulary(chat.Chat, VocabularyOptions{MaxNgram: 1, MinCount: 2, Top: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Distinctive["Alice"]; len(got) != 1 || got[0].Term != "pizza" {
+		t.Errorf("Alice's distinctive terms = %+v; want only pizza", got)
+	}
+	if got := report.Distinctive["Bob"]; len(got) != 1 || got[0].Term != "sushi" {
+		t.Errorf("Bob's distinctive terms = %+v; want only sushi", got)
+	}
+	// hello and tonight are shared too, but they come up almost every day, so
+	// their TF-IDF weight ranks them below the one-off weekend plans.
+	var shared []string
+	for _, term := range report.Shared {
+		shared = append(shared, term.Term)
+	}
+	if !reflect.DeepEqual(shared, []string{"plans", "weekend", "hello", "tonight"}) {
+		t.Errorf("shared terms = %v; want plans, weekend, hello, tonight", shared)
+	}
+}
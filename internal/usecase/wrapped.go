@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"fmt"
+	"telegram-chat-analyzer/internal/domain"
+	"time"
+)
+
+// WrappedOptions controls Wrapped.
+type WrappedOptions struct {
+	// Location is the timezone the year and its days are read in. Nil keeps the export's wall clock.
+	Location *time.Location
+	// Year is the calendar year to summarise. Zero picks the year of the latest message.
+	Year int
+	// SessionGap splits conversations and bounds what counts as a reply.
+	SessionGap time.Duration
+	// Top is how many words and emoji to return.
+	Top int
+}
+
+// Wrapped builds a year-in-review summary by running the existing analyses
+// on the messages of one calendar year: top words and emoji, the busiest
+// month, date, weekday and hour, the longest streaks, who replies fastest,
+// time spent in calls and the biggest conversation.
+func (u *messageUsecase) Wrapped(chat domain.Chat, opts WrappedOptions) (domain.Wrapped, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	year := opts.Year
+	if year == 0 {
+		_, last, ok := chatTimeRange(chat, opts.Location)
+		if !ok {
+			return domain.Wrapped{}, fmt.Errorf("no dated messages to summarise")
+		}
+		year = last.Year()
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+	yearChat := chatBetween(chat, opts.Location, start, end)
+
+	// Calls are service messages, which the remaining analyses skip.
+	var calls domain.CallSummary
+	for _, message := range yearChat.Messages {
+		if isServiceMessage(message) && (message.Action == "phone_call" || message.Action == "group_call") {
+			calls.Calls++
+			calls.TotalSeconds += message.DurationSeconds
+		}
+	}
+
+	// The participants come from the whole chat, so a quiet year still
+	// reports on the same two people.
+	personOne, personTwo := u.GetPersons(chat)
+	participants := []string{personOne, personTwo}
+	var messages []timedMessage
+	for _, message := range u.sortedMessages(chat, opts.Location) {
+		if !message.At.Before(start) && message.At.Before(end) {
+			messages = append(messages, message)
+		}
+	}
+
+	wrapped := domain.Wrapped{
+		Year:               year,
+		Messages:           map[string]int{personOne: 0, personTwo: 0, "overall": len(messages)},
+		TopWords:           []domain.EntityCount{},
+		TopEmoji:           []domain.EntityCount{},
+		LongestStreak:      make(map[string]domain.Streak),
+		MedianReplySeconds: make(map[string]float64),
+		Calls:              calls,
+	}
+	if len(messages) == 0 {
+		return wrapped, nil
+	}
+
+	replies := make(map[string][]float64)
+	for i, message := range messages {
+		wrapped.Messages[message.From]++
+		if delay, ok := replyDelay(messages, i, opts.SessionGap); ok {
+			replies[message.From] = append(replies[message.From], delay.Seconds())
+		}
+	}
+	wrapped.TopWords, wrapped.TopEmoji = topWordsAndEmoji(messages, participants, opts.Top)
+
+	for _, person := range participants {
+		if len(replies[person]) == 0 {
+			continue
+		}
+		wrapped.MedianReplySeconds[person] = roundTo(median(replies[person]), 1)
+		if wrapped.FastestReplier == "" || wrapped.MedianReplySeconds[person] < wrapped.MedianReplySeconds[wrapped.FastestReplier] {
+			wrapped.FastestReplier = person
+		}
+	}
+
+	for granularity, target := range map[string]*domain.PeriodCount{GranularityMonth: &wrapped.BusiestMonth, GranularityDay: &wrapped.BusiestDate} {
+		series, err := u.TimeSeries(yearChat, SeriesOptions{Location: opts.Location, Granularity: granularity, WeekStart: time.Monday})
+		if err != nil {
+			return domain.Wrapped{}, err
+		}
+		for i, count := range series.Series[MetricMessages]["overall"].Values {
+			if count > target.Messages {
+				*target = domain.PeriodCount{Period: series.Periods[i], Messages: count}
+			}
+		}
+	}
+	if wrapped.BusiestMonth.Period != "" {
+		wrapped.BusiestMonth.Period = wrapped.BusiestMonth.Period[:7]
+	}
+
+	heatmap, err := u.ActivityHeatmap(yearChat, HeatmapOptions{Location: opts.Location, WeekStart: time.Monday, Normalize: NormalizeNone})
+	if err != nil {
+		return domain.Wrapped{}, err
+	}
+	var hours [24]int
+	for row, counts := range heatmap.Grids["overall"] {
+		weekday := 0
+		for hour, count := range counts {
+			hours[hour] += int(count)
+			weekday += int(count)
+		}
+		if weekday > wrapped.BusiestWeekday.Messages {
+			wrapped.BusiestWeekday = domain.PeriodCount{Period: heatmap.Days[row], Messages: weekday}
+		}
+	}
+	for hour, count := range hours {
+		if count > wrapped.BusiestHour.Messages {
+			wrapped.BusiestHour = domain.PeriodCount{Period: fmt.Sprintf("%02d:00", hour), Messages: count}
+		}
+	}
+
+	streaks, err := u.Streaks(yearChat, StreakOptions{Location: opts.Location, AsOf: end.AddDate(0, 0, -1)})
+	if err != nil {
+		return domain.Wrapped{}, err
+	}
+	for key, streak := range streaks.Longest {
+		if key == personOne || key == personTwo || key == "overall" {
+			wrapped.LongestStreak[key] = streak
+		}
+	}
+
+	for _, s := range splitSessions(messages, opts.SessionGap) {
+		if len(s.Messages) > wrapped.BiggestConversation.Messages {
+			wrapped.BiggestConversation = s.Conversation()
+		}
+	}
+	return wrapped, nil
+}
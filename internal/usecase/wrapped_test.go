@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWrapped(t *testing.T) {
+	chat := newTestChat()
+	for day := 0; day < 10; day++ {
+		at := date(2023, 12, 28, 9, 0).AddDate(0, 0, day)
+		chat.Add(at, "Alice", "pizza tonight 😂").
+			Add(at.Add(time.Minute), "Bob", "yes pizza 😂").
+			Add(at.Add(3*time.Minute), "Alice", "great")
+	}
+	chat.Service(date(2024, 1, 3, 20, 0), "phone_call", 120).
+		Service(date(2023, 12, 29, 20, 0), "phone_call", 600)
+
+	wrapped, err := newTestUsecase(t).Wrapped(chat.Chat, WrappedOptions{SessionGap: time.Hour, Top: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrapped.Year != 2024 || wrapped.Messages["overall"] != 18 || wrapped.Messages["Alice"] != 12 {
+		t.Errorf("year %d, messages %v; want 2024 with 18 messages, 12 from Alice", wrapped.Year, wrapped.Messages)
+	}
+	if len(wrapped.TopWords) == 0 || wrapped.TopWords[0].Text != "pizza" {
+		t.Errorf("top words = %+v; want pizza first", wrapped.TopWords)
+	}
+	if len(wrapped.TopEmoji) != 1 || wrapped.TopEmoji[0].Total != 12 {
+		t.Errorf("top emoji = %+v; want one emoji used 12 times", wrapped.TopEmoji)
+	}
+	if wrapped.Calls.Calls != 1 || wrapped.Calls.TotalSeconds != 120 {
+		t.Errorf("calls = %+v; want only the 2024 call", wrapped.Calls)
+	}
+	if wrapped.LongestStreak["overall"].Length != 6 {
+		t.Errorf("longest streak = %+v; want the 6 days of 2024", wrapped.LongestStreak["overall"])
+	}
+	if wrapped.FastestReplier != "Bob" || wrapped.MedianReplySeconds["Alice"] != 120 {
+		t.Errorf("fastest %q, medians %v; want Bob, Alice at 120s", wrapped.FastestReplier, wrapped.MedianReplySeconds)
+	}
+	if wrapped.BiggestConversation.Messages != 3 || wrapped.BusiestHour.Period != "09:00" {
+		t.Errorf("biggest conversation %+v, busiest hour %+v", wrapped.BiggestConversation, wrapped.BusiestHour)
+	}
+}
+
+func TestWrappedQuietYear(t *testing.T) {
+	chat := newTestChat().
+		Add(date(2024, 1, 1, 9, 0), "Alice", "hi").
+		Add(date(2024, 1, 1, 9, 1), "Bob", "hey")
+
+	wrapped, err := newTestUsecase(t).Wrapped(chat.Chat, WrappedOptions{Year: 2020, SessionGap: time.Hour, Top: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"Alice": 0, "Bob": 0, "overall": 0}
+	if !reflect.DeepEqual(wrapped.Messages, want) || len(wrapped.LongestStreak) != 0 {
+		t.Errorf("messages %v, streaks %v; want zero counts for Alice and Bob and no streaks", wrapped.Messages, wrapped.LongestStreak)
+	}
+}